@@ -0,0 +1,10 @@
+data "cockroachdb_database" "shared" {
+  name = "shared"
+}
+
+resource "cockroachdb_grant" "shared_connect" {
+  role        = "reporting"
+  database    = data.cockroachdb_database.shared.name
+  object_type = "database"
+  privileges  = ["CONNECT"]
+}
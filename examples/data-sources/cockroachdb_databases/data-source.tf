@@ -0,0 +1,8 @@
+data "cockroachdb_databases" "team" {
+  name_regex = "^team_"
+  owner      = "team_admin"
+}
+
+output "team_databases" {
+  value = [for db in data.cockroachdb_databases.team.databases : db.name]
+}
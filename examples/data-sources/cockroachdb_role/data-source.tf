@@ -0,0 +1,7 @@
+data "cockroachdb_role" "app" {
+  name = "app"
+}
+
+output "app_members" {
+  value = data.cockroachdb_role.app.members
+}
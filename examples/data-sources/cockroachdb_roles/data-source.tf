@@ -0,0 +1,9 @@
+data "cockroachdb_roles" "services" {
+  name_regex = "^svc_"
+  login      = true
+  member_of  = "app"
+}
+
+output "service_roles" {
+  value = [for role in data.cockroachdb_roles.services.roles : role.name]
+}
@@ -0,0 +1,25 @@
+resource "cockroachdb_role" "app" {
+  name  = "app"
+  login = true
+}
+
+resource "cockroachdb_client_certificate" "app" {
+  username    = cockroachdb_role.app.name
+  ca_cert_pem = file("certs/ca.crt")
+  ca_key_pem  = file("my-safe-directory/ca.key")
+
+  key_algorithm         = "ECDSA"
+  validity_period_hours = 8760
+  early_renewal_hours   = 720
+}
+
+resource "local_sensitive_file" "app_key" {
+  filename        = "certs/client.app.key"
+  content         = cockroachdb_client_certificate.app.private_key_pem
+  file_permission = "0600"
+}
+
+resource "local_file" "app_cert" {
+  filename = "certs/client.app.crt"
+  content  = cockroachdb_client_certificate.app.cert_pem
+}
@@ -1,3 +1,19 @@
 resource "cockroachdb_database" "test_database" {
   name = "test_database"
 }
+
+resource "cockroachdb_database" "test_database_with_defaults" {
+  name = "test_database_with_defaults"
+  session_defaults = {
+    statement_timeout = "30s"
+  }
+}
+
+resource "cockroachdb_database" "test_database_with_backup" {
+  name = "test_database_with_backup"
+
+  backup_before_destroy {
+    destination      = "nodelocal://1/backups"
+    revision_history = true
+  }
+}
@@ -0,0 +1,17 @@
+resource "cockroachdb_role" "test_role" {
+  name = "test_role"
+}
+
+resource "cockroachdb_role" "test_migrator" {
+  name = "test_migrator"
+}
+
+# Every table test_migrator creates in public becomes readable by test_role
+resource "cockroachdb_default_privileges" "test_role_tables" {
+  role        = cockroachdb_role.test_role.name
+  database    = "defaultdb"
+  schema      = "public"
+  target_role = cockroachdb_role.test_migrator.name
+  object_type = "table"
+  privileges  = ["SELECT"]
+}
@@ -0,0 +1,22 @@
+resource "cockroachdb_role" "test_role" {
+  name = "test_role"
+}
+
+# Each team adds the privileges it needs without revoking the other's
+resource "cockroachdb_grant_privilege" "test_role_reporting" {
+  role        = cockroachdb_role.test_role.name
+  database    = "defaultdb"
+  schema      = "public"
+  object_type = "table"
+  objects     = ["orders"]
+  privileges  = ["SELECT"]
+}
+
+resource "cockroachdb_grant_privilege" "test_role_billing" {
+  role        = cockroachdb_role.test_role.name
+  database    = "defaultdb"
+  schema      = "public"
+  object_type = "table"
+  objects     = ["orders"]
+  privileges  = ["UPDATE"]
+}
@@ -0,0 +1,25 @@
+resource "cockroachdb_hba_config" "cluster" {
+  # Services on the internal network authenticate with client certificates
+  entry {
+    type     = "hostssl"
+    database = "all"
+    user     = "all"
+    address  = "10.0.0.0/8"
+    method   = "cert"
+  }
+
+  entry {
+    type     = "host"
+    database = "all"
+    user     = "all"
+    address  = "all"
+    method   = "cert-password"
+  }
+
+  entry {
+    type     = "local"
+    database = "all"
+    user     = "all"
+    method   = "password"
+  }
+}
@@ -10,12 +10,54 @@ resource "cockroachdb_grant" "test_role_grant" {
 }
 
 resource "cockroachdb_role" "test_user" {
-  name     = "test_user"
-  login    = true
-  password = "test_password_a1s2d3f4"
+  name        = "test_user"
+  login       = true
+  password    = "test_password_a1s2d3f4"
+  valid_until = "2030-01-01T00:00:00Z"
 }
 
 resource "cockroachdb_grant_role" "test_user_grant_test_role" {
   role       = cockroachdb_role.test_user.name
   grant_role = cockroachdb_role.test_role.name
 }
+
+resource "cockroachdb_role" "test_operator" {
+  name          = "test_operator"
+  control_job   = true
+  view_activity = true
+  cancel_query  = true
+}
+
+resource "cockroachdb_role" "test_service" {
+  name         = "test_service"
+  login        = true
+  member_of    = [cockroachdb_role.test_role.name]
+  rotate_after = "2160h"
+
+  generate_password {
+    length  = 40
+    special = true
+  }
+
+  rotation_triggers = {
+    deployment = "2024-01"
+  }
+}
+
+variable "test_write_only_password" {
+  type      = string
+  sensitive = true
+}
+
+resource "cockroachdb_role" "test_write_only" {
+  name                = "test_write_only"
+  login               = true
+  password_wo         = var.test_write_only_password
+  password_wo_version = 1
+}
+
+resource "cockroachdb_role" "test_service_owner" {
+  name              = "test_service_owner"
+  reassign_owned_to = "admin"
+  drop_owned        = true
+}
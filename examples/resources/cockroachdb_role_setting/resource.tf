@@ -0,0 +1,20 @@
+resource "cockroachdb_role" "app" {
+  name = "app"
+}
+
+resource "cockroachdb_database" "app" {
+  name = "app"
+}
+
+resource "cockroachdb_role_setting" "app_statement_timeout" {
+  role     = cockroachdb_role.app.name
+  database = cockroachdb_database.app.name
+  variable = "statement_timeout"
+  value    = "30s"
+}
+
+resource "cockroachdb_role_setting" "all_follower_reads" {
+  role     = "ALL"
+  variable = "default_transaction_use_follower_reads"
+  value    = "on"
+}
@@ -0,0 +1,8 @@
+resource "cockroachdb_role" "test_role" {
+  name = "test_role"
+}
+
+resource "cockroachdb_system_privileges" "test_role_system_privileges" {
+  role       = cockroachdb_role.test_role.name
+  privileges = ["VIEWACTIVITY", "VIEWCLUSTERSETTING"]
+}
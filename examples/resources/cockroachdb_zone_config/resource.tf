@@ -0,0 +1,26 @@
+resource "cockroachdb_database" "app" {
+  name = "app"
+}
+
+resource "cockroachdb_zone_config" "app" {
+  database      = cockroachdb_database.app.name
+  num_replicas  = 5
+  gc_ttlseconds = 3600
+  constraints   = ["+region=us-east1"]
+  lease_preferences = [
+    ["+region=us-east1", "+zone=us-east1-b"],
+    ["+region=us-east1"],
+  ]
+}
+
+resource "cockroachdb_zone_config" "users_email_idx" {
+  database     = cockroachdb_database.app.name
+  table        = "users"
+  index        = "users_email_idx"
+  num_replicas = 3
+}
+
+resource "cockroachdb_zone_config" "liveness" {
+  range        = "liveness"
+  num_replicas = 7
+}
@@ -0,0 +1,170 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &dataSourceDatabase{}
+	_ datasource.DataSourceWithConfigure = &dataSourceDatabase{}
+)
+
+func NewDatabaseDataSource() datasource.DataSource {
+	return &dataSourceDatabase{}
+}
+
+type dataSourceDatabase struct {
+	p *cockroachdbProvider
+}
+
+func (d *dataSourceDatabase) Metadata(_ context.Context, _ datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = "cockroachdb_database"
+}
+
+func (d *dataSourceDatabase) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up an existing database.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description: "Name of the database",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "ID of the database",
+				Computed:    true,
+			},
+			"owner": schema.StringAttribute{
+				Description: "Owner of the database",
+				Computed:    true,
+			},
+			"primary_region": schema.StringAttribute{
+				Description: "Primary region of a multi-region database",
+				Computed:    true,
+			},
+			"regions": schema.ListAttribute{
+				Description: "Regions of a multi-region database",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"survival_goal": schema.StringAttribute{
+				Description: "Survival goal of a multi-region database",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *dataSourceDatabase) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.p = req.ProviderData.(*cockroachdbProvider)
+}
+
+// databaseInfoQuery selects the columns scanned by scanDatabaseInfo
+const databaseInfoQuery = `SELECT id, name, owner, primary_region, regions, survival_goal FROM crdb_internal.databases`
+
+// scanDatabaseInfo maps a row of databaseInfoQuery onto a DatabaseInfo
+func scanDatabaseInfo(ctx context.Context, row pgx.Row) (DatabaseInfo, error) {
+	var (
+		id            int
+		name          string
+		owner         string
+		primaryRegion pgtype.Text
+		regions       []string
+		survivalGoal  pgtype.Text
+		info          DatabaseInfo
+	)
+
+	err := row.Scan(&id, &name, &owner, &primaryRegion, &regions, &survivalGoal)
+	if err != nil {
+		return info, err
+	}
+
+	info.ID = types.StringValue(strconv.Itoa(id))
+	info.Name = types.StringValue(name)
+	info.Owner = types.StringValue(owner)
+	info.PrimaryRegion = types.StringNull()
+	if primaryRegion.Valid {
+		info.PrimaryRegion = types.StringValue(primaryRegion.String)
+	}
+	info.SurvivalGoal = types.StringNull()
+	if survivalGoal.Valid {
+		info.SurvivalGoal = types.StringValue(survivalGoal.String)
+	}
+	if regions == nil {
+		regions = []string{}
+	}
+	info.Regions, _ = types.ListValueFrom(ctx, types.StringType, regions)
+
+	return info, nil
+}
+
+func (d *dataSourceDatabase) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config DatabaseInfo
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Connect to db
+	conn, err := d.p.Conn(ctx, "")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach connection error",
+			err.Error(),
+		)
+		return
+	}
+	defer conn.Close(ctx)
+
+	info, err := scanDatabaseInfo(ctx, conn.QueryRow(ctx, databaseInfoQuery+` WHERE name = $1`, config.Name.ValueString()))
+	if errors.Is(err, pgx.ErrNoRows) {
+		resp.Diagnostics.AddError(
+			"Database not found",
+			fmt.Sprintf("Database %s does not exist", config.Name.ValueString()),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach execute sql error",
+			err.Error(),
+		)
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &info)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+type DatabaseInfo struct {
+	ID            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	Owner         types.String `tfsdk:"owner"`
+	PrimaryRegion types.String `tfsdk:"primary_region"`
+	Regions       types.List   `tfsdk:"regions"`
+	SurvivalGoal  types.String `tfsdk:"survival_goal"`
+}
@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatabaseDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: prefixProvider(`
+resource "cockroachdb_database" "test_database" {
+	name = "test_database_lookup"
+	owner = "root"
+}
+
+data "cockroachdb_database" "test_database" {
+	name = cockroachdb_database.test_database.name
+}
+
+data "cockroachdb_databases" "test_databases" {
+	name_regex = "^test_database_look"
+	owner      = "root"
+
+	depends_on = [cockroachdb_database.test_database]
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cockroachdb_database.test_database", "name", "test_database_lookup"),
+					resource.TestCheckResourceAttr("data.cockroachdb_database.test_database", "owner", "root"),
+					resource.TestCheckResourceAttrPair("data.cockroachdb_database.test_database", "id", "cockroachdb_database.test_database", "id"),
+					resource.TestCheckResourceAttr("data.cockroachdb_database.test_database", "regions.#", "0"),
+					resource.TestCheckResourceAttr("data.cockroachdb_databases.test_databases", "databases.#", "1"),
+					resource.TestCheckResourceAttr("data.cockroachdb_databases.test_databases", "databases.0.name", "test_database_lookup"),
+				),
+			},
+		},
+	})
+}
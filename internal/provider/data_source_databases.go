@@ -0,0 +1,182 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &dataSourceDatabases{}
+	_ datasource.DataSourceWithConfigure = &dataSourceDatabases{}
+)
+
+func NewDatabasesDataSource() datasource.DataSource {
+	return &dataSourceDatabases{}
+}
+
+type dataSourceDatabases struct {
+	p *cockroachdbProvider
+}
+
+func (d *dataSourceDatabases) Metadata(_ context.Context, _ datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = "cockroachdb_databases"
+}
+
+func (d *dataSourceDatabases) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the databases matching the given filters.",
+		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				Description: "Only return databases whose name matches this regular expression",
+				Optional:    true,
+			},
+			"owner": schema.StringAttribute{
+				Description: "Only return databases owned by this role",
+				Optional:    true,
+			},
+			"databases": schema.ListNestedAttribute{
+				Description: "Matching databases, ordered by name",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "ID of the database",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the database",
+							Computed:    true,
+						},
+						"owner": schema.StringAttribute{
+							Description: "Owner of the database",
+							Computed:    true,
+						},
+						"primary_region": schema.StringAttribute{
+							Description: "Primary region of a multi-region database",
+							Computed:    true,
+						},
+						"regions": schema.ListAttribute{
+							Description: "Regions of a multi-region database",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"survival_goal": schema.StringAttribute{
+							Description: "Survival goal of a multi-region database",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *dataSourceDatabases) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.p = req.ProviderData.(*cockroachdbProvider)
+}
+
+func (d *dataSourceDatabases) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state Databases
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Connect to db
+	conn, err := d.p.Conn(ctx, "")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach connection error",
+			err.Error(),
+		)
+		return
+	}
+	defer conn.Close(ctx)
+
+	// Build query from the configured filters
+	filters := []string{}
+	args := []any{}
+	if !state.NameRegex.IsNull() {
+		args = append(args, state.NameRegex.ValueString())
+		filters = append(filters, "name ~ $1")
+	}
+	if !state.Owner.IsNull() {
+		args = append(args, state.Owner.ValueString())
+		filters = append(filters, fmt.Sprintf("owner = $%d", len(args)))
+	}
+
+	query := databaseInfoQuery
+	if len(filters) > 0 {
+		query += " WHERE " + strings.Join(filters, " AND ")
+	}
+	query += " ORDER BY name"
+
+	rows, err := conn.Query(ctx, query, args...)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach execute sql error",
+			err.Error(),
+		)
+		return
+	}
+	defer rows.Close()
+
+	databases := []DatabaseInfo{}
+	for rows.Next() {
+		info, err := scanDatabaseInfo(ctx, rows)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Cockroach execute sql error",
+				err.Error(),
+			)
+			return
+		}
+		databases = append(databases, info)
+	}
+	if err := rows.Err(); err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach execute sql error",
+			err.Error(),
+		)
+		return
+	}
+
+	state.Databases, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: map[string]attr.Type{
+		"id":             types.StringType,
+		"name":           types.StringType,
+		"owner":          types.StringType,
+		"primary_region": types.StringType,
+		"regions":        types.ListType{ElemType: types.StringType},
+		"survival_goal":  types.StringType,
+	}}, databases)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+type Databases struct {
+	NameRegex types.String `tfsdk:"name_regex"`
+	Owner     types.String `tfsdk:"owner"`
+	Databases types.List   `tfsdk:"databases"`
+}
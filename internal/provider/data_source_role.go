@@ -0,0 +1,191 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &dataSourceRole{}
+	_ datasource.DataSourceWithConfigure = &dataSourceRole{}
+)
+
+func NewRoleDataSource() datasource.DataSource {
+	return &dataSourceRole{}
+}
+
+type dataSourceRole struct {
+	p *cockroachdbProvider
+}
+
+func (d *dataSourceRole) Metadata(_ context.Context, _ datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = "cockroachdb_role"
+}
+
+func (d *dataSourceRole) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up an existing role by name.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description: "Name of the role",
+				Required:    true,
+			},
+			"id": schema.StringAttribute{
+				Description: "ID of the role (it's really just the name because they have to be unique)",
+				Computed:    true,
+			},
+			"login": schema.BoolAttribute{
+				Description: "Whether the role is allowed to log in",
+				Computed:    true,
+			},
+			"options": schema.SetAttribute{
+				Description: "Role options that are turned on, e.g. `CREATEDB` or `VIEWACTIVITY`",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"valid_until": schema.StringAttribute{
+				Description: "Date and time (RFC 3339) after which the role's password is no longer valid, or `infinity`",
+				Computed:    true,
+			},
+			"member_of": schema.SetAttribute{
+				Description: "Names of the roles this role is a member of",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"members": schema.SetAttribute{
+				Description: "Names of the roles that are members of this role",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *dataSourceRole) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.p = req.ProviderData.(*cockroachdbProvider)
+}
+
+// readRoleMembers returns the names of the roles that are members of the role
+func readRoleMembers(ctx context.Context, conn dbExecutor, role string) ([]string, error) {
+	rows, err := conn.Query(ctx, `SELECT member FROM system.role_members WHERE "role" = $1 ORDER BY member`, role)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+// readRoleInfo fills in a RoleInfo from a row of roleInfoQuery
+func readRoleInfo(ctx context.Context, conn dbExecutor, roleRow map[string]interface{}) (RoleInfo, error) {
+	name := roleRow["name"].(string)
+	info := RoleInfo{
+		ID:         types.StringValue(name),
+		Name:       types.StringValue(name),
+		Login:      types.BoolValue(roleRow["login"].(bool)),
+		ValidUntil: readValidUntil(roleRow["valid_until"].(pgtype.Timestamptz), types.StringNull()),
+	}
+
+	options, err := readRoleOptions(ctx, conn, roleRow)
+	if err != nil {
+		return info, err
+	}
+	optionNames := []string{}
+	for option, on := range options {
+		if on {
+			optionNames = append(optionNames, option)
+		}
+	}
+	sort.Strings(optionNames)
+
+	memberOf, err := readRoleMemberships(ctx, conn, name)
+	if err != nil {
+		return info, err
+	}
+
+	members, err := readRoleMembers(ctx, conn, name)
+	if err != nil {
+		return info, err
+	}
+
+	info.Options, _ = types.SetValueFrom(ctx, types.StringType, optionNames)
+	info.MemberOf, _ = types.SetValueFrom(ctx, types.StringType, memberOf)
+	info.Members, _ = types.SetValueFrom(ctx, types.StringType, members)
+
+	return info, nil
+}
+
+func (d *dataSourceRole) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config RoleInfo
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Connect to db
+	conn, err := d.p.Conn(ctx, "")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach connection error",
+			err.Error(),
+		)
+		return
+	}
+	defer conn.Close(ctx)
+
+	roleRow, err := GetRoleByKeyValue(conn, ctx, "rolname", config.Name.ValueString())
+	if errors.Is(err, pgx.ErrNoRows) {
+		resp.Diagnostics.AddError(
+			"Role not found",
+			fmt.Sprintf("Role %s does not exist", config.Name.ValueString()),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach execute sql error",
+			err.Error(),
+		)
+		return
+	}
+
+	info, err := readRoleInfo(ctx, conn, roleRow)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach execute sql error",
+			err.Error(),
+		)
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &info)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+type RoleInfo struct {
+	ID         types.String `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	Login      types.Bool   `tfsdk:"login"`
+	Options    types.Set    `tfsdk:"options"`
+	ValidUntil types.String `tfsdk:"valid_until"`
+	MemberOf   types.Set    `tfsdk:"member_of"`
+	Members    types.Set    `tfsdk:"members"`
+}
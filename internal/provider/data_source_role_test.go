@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccRoleDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: prefixProvider(`
+resource "cockroachdb_role" "lookup_parent" {
+	name = "lookup_parent"
+}
+
+resource "cockroachdb_role" "lookup_child" {
+	name            = "lookup_child"
+	login           = true
+	create_database = true
+	member_of       = [cockroachdb_role.lookup_parent.name]
+}
+
+data "cockroachdb_role" "lookup_child" {
+	name = cockroachdb_role.lookup_child.name
+}
+
+data "cockroachdb_role" "lookup_parent" {
+	name = cockroachdb_role.lookup_parent.name
+
+	depends_on = [cockroachdb_role.lookup_child]
+}
+
+data "cockroachdb_roles" "lookup" {
+	name_regex = "^lookup_"
+	login      = true
+	member_of  = "lookup_parent"
+
+	depends_on = [cockroachdb_role.lookup_child]
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cockroachdb_role.lookup_child", "login", "true"),
+					resource.TestCheckTypeSetElemAttr("data.cockroachdb_role.lookup_child", "options.*", "CREATEDB"),
+					resource.TestCheckTypeSetElemAttr("data.cockroachdb_role.lookup_child", "member_of.*", "lookup_parent"),
+					resource.TestCheckTypeSetElemAttr("data.cockroachdb_role.lookup_parent", "members.*", "lookup_child"),
+					resource.TestCheckResourceAttr("data.cockroachdb_roles.lookup", "roles.#", "1"),
+					resource.TestCheckResourceAttr("data.cockroachdb_roles.lookup", "roles.0.name", "lookup_child"),
+				),
+			},
+		},
+	})
+}
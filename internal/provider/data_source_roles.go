@@ -0,0 +1,203 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jackc/pgx/v5"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &dataSourceRoles{}
+	_ datasource.DataSourceWithConfigure = &dataSourceRoles{}
+)
+
+func NewRolesDataSource() datasource.DataSource {
+	return &dataSourceRoles{}
+}
+
+type dataSourceRoles struct {
+	p *cockroachdbProvider
+}
+
+func (d *dataSourceRoles) Metadata(_ context.Context, _ datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = "cockroachdb_roles"
+}
+
+func (d *dataSourceRoles) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the roles matching the given filters.",
+		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				Description: "Only return roles whose name matches this regular expression",
+				Optional:    true,
+			},
+			"login": schema.BoolAttribute{
+				Description: "Only return roles that can (true) or can't (false) log in",
+				Optional:    true,
+			},
+			"member_of": schema.StringAttribute{
+				Description: "Only return roles that are members of this role",
+				Optional:    true,
+			},
+			"roles": schema.ListNestedAttribute{
+				Description: "Matching roles, ordered by name",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "ID of the role",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the role",
+							Computed:    true,
+						},
+						"login": schema.BoolAttribute{
+							Description: "Whether the role is allowed to log in",
+							Computed:    true,
+						},
+						"options": schema.SetAttribute{
+							Description: "Role options that are turned on, e.g. `CREATEDB` or `VIEWACTIVITY`",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"valid_until": schema.StringAttribute{
+							Description: "Date and time (RFC 3339) after which the role's password is no longer valid, or `infinity`",
+							Computed:    true,
+						},
+						"member_of": schema.SetAttribute{
+							Description: "Names of the roles this role is a member of",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"members": schema.SetAttribute{
+							Description: "Names of the roles that are members of this role",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *dataSourceRoles) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.p = req.ProviderData.(*cockroachdbProvider)
+}
+
+func (d *dataSourceRoles) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state Roles
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Connect to db
+	conn, err := d.p.Conn(ctx, "")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach connection error",
+			err.Error(),
+		)
+		return
+	}
+	defer conn.Close(ctx)
+
+	// Build query from the configured filters
+	filters := []string{}
+	args := []any{}
+	if !state.NameRegex.IsNull() {
+		args = append(args, state.NameRegex.ValueString())
+		filters = append(filters, fmt.Sprintf("rolname ~ $%d", len(args)))
+	}
+	if !state.Login.IsNull() {
+		args = append(args, state.Login.ValueBool())
+		filters = append(filters, fmt.Sprintf("rolcanlogin = $%d", len(args)))
+	}
+	if !state.MemberOf.IsNull() {
+		args = append(args, state.MemberOf.ValueString())
+		filters = append(filters, fmt.Sprintf(`rolname IN (SELECT member FROM system.role_members WHERE "role" = $%d)`, len(args)))
+	}
+
+	query := roleInfoQuery
+	if len(filters) > 0 {
+		query += " WHERE " + strings.Join(filters, " AND ")
+	}
+	query += " ORDER BY rolname"
+
+	rows, err := conn.Query(ctx, query, args...)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach execute sql error",
+			err.Error(),
+		)
+		return
+	}
+
+	// Collect the rows first, the connection is needed again for each role
+	roleRows, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (map[string]interface{}, error) {
+		return scanRoleInfo(row)
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach execute sql error",
+			err.Error(),
+		)
+		return
+	}
+
+	roles := []RoleInfo{}
+	for _, roleRow := range roleRows {
+		info, err := readRoleInfo(ctx, conn, roleRow)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Cockroach execute sql error",
+				err.Error(),
+			)
+			return
+		}
+		roles = append(roles, info)
+	}
+
+	state.Roles, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: map[string]attr.Type{
+		"id":          types.StringType,
+		"name":        types.StringType,
+		"login":       types.BoolType,
+		"options":     types.SetType{ElemType: types.StringType},
+		"valid_until": types.StringType,
+		"member_of":   types.SetType{ElemType: types.StringType},
+		"members":     types.SetType{ElemType: types.StringType},
+	}}, roles)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+type Roles struct {
+	NameRegex types.String `tfsdk:"name_regex"`
+	Login     types.Bool   `tfsdk:"login"`
+	MemberOf  types.String `tfsdk:"member_of"`
+	Roles     types.List   `tfsdk:"roles"`
+}
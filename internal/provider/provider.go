@@ -179,13 +179,25 @@ func (p *cockroachdbProvider) Configure(ctx context.Context, req provider.Config
 
 func (p *cockroachdbProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
+		NewClientCertificateResource,
 		NewDatabaseResource,
+		NewDefaultPrivilegesResource,
+		NewGrantPrivilegeResource,
 		NewGrantRoleResource,
 		NewGrantResource,
+		NewHbaConfigResource,
 		NewRoleResource,
+		NewRoleSettingResource,
+		NewSystemPrivilegesResource,
+		NewZoneConfigResource,
 	}
 }
 
 func (p *cockroachdbProvider) DataSources(_ context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewDatabaseDataSource,
+		NewDatabasesDataSource,
+		NewRoleDataSource,
+		NewRolesDataSource,
+	}
 }
@@ -0,0 +1,316 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"telusag/terraform-provider-cockroachdb/internal/modifiers"
+	"telusag/terraform-provider-cockroachdb/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// defaultClientCertValidityHours matches the client certificate lifetime
+	// of `cockroach cert create-client`, 5 years
+	defaultClientCertValidityHours = 43800
+	defaultClientCertRSABits       = 2048
+	// systemTenantID is the tenant client certificates are scoped to by default
+	systemTenantID = 1
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource               = &resourceClientCertificate{}
+	_ resource.ResourceWithModifyPlan = &resourceClientCertificate{}
+)
+
+func NewClientCertificateResource() resource.Resource {
+	return &resourceClientCertificate{}
+}
+
+// resourceClientCertificate issues certificates locally, it never connects
+// to the cluster
+type resourceClientCertificate struct{}
+
+func (r *resourceClientCertificate) Metadata(_ context.Context, _ resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = "cockroachdb_client_certificate"
+}
+
+func (r *resourceClientCertificate) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Issues a client certificate for a SQL user, signed by the cluster's CA. The key pair is generated and signed locally, like `cockroach cert create-client`.",
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				Description: "SQL user the certificate authenticates as",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Description: "PEM encoded certificate of the CA that signs client certificates",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ca_key_pem": schema.StringAttribute{
+				Description: "PEM encoded private key of the CA",
+				Required:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key_algorithm": schema.StringAttribute{
+				Description: "Algorithm of the generated key: `RSA`, `ECDSA` (P-256) or `ED25519`. Default value is `RSA`.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(utils.KeyAlgorithmRSA, utils.KeyAlgorithmECDSA, utils.KeyAlgorithmED25519),
+				},
+				PlanModifiers: []planmodifier.String{
+					modifiers.StringDefault(utils.KeyAlgorithmRSA),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"rsa_bits": schema.Int64Attribute{
+				Description: "Size of the generated RSA key. Default value is 2048.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.OneOf(2048, 3072, 4096),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					modifiers.Int64Default(defaultClientCertRSABits),
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"validity_period_hours": schema.Int64Attribute{
+				Description: "Number of hours the certificate is valid for. It never outlives the CA certificate. Default value is 43800 (5 years).",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					modifiers.Int64Default(defaultClientCertValidityHours),
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"early_renewal_hours": schema.Int64Attribute{
+				Description: "Re-issue the certificate on the first apply within this many hours of it expiring. Default value is 0, re-issue once it has expired.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					modifiers.Int64Default(0),
+				},
+			},
+			"tenant_scope": schema.ListAttribute{
+				Description: "IDs of the tenants the certificate is valid for, added as `crdb://tenant/<id>/user/<username>` URI SANs. Default value is the system tenant, `[1]`.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.Int64Type,
+				Validators: []validator.List{
+					listvalidator.ValueInt64sAre(int64validator.AtLeast(1)),
+				},
+				PlanModifiers: []planmodifier.List{
+					modifiers.ListDefault([]attr.Value{types.Int64Value(systemTenantID)}),
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"cert_pem": schema.StringAttribute{
+				Description: "PEM encoded client certificate, e.g. for `client.<username>.crt`",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"private_key_pem": schema.StringAttribute{
+				Description: "PEM encoded private key, PKCS #1 for RSA and SEC 1 for ECDSA keys, e.g. for `client.<username>.key`",
+				Computed:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"private_key_pkcs8_pem": schema.StringAttribute{
+				Description: "PEM encoded PKCS #8 private key, as needed by JDBC",
+				Computed:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"validity_start_time": schema.StringAttribute{
+				Description: "Time (RFC 3339) the certificate becomes valid",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"validity_end_time": schema.StringAttribute{
+				Description: "Time (RFC 3339) the certificate expires",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Serial number of the certificate",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ModifyPlan re-issues the certificate once it is within early_renewal_hours
+// of expiring
+func (r *resourceClientCertificate) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to renew on create or destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var (
+		plan  ClientCertificate
+		state ClientCertificate
+	)
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validityEnd, err := time.Parse(time.RFC3339, state.ValidityEndTime.ValueString())
+	if err != nil {
+		return
+	}
+
+	renewAt := validityEnd.Add(-time.Duration(plan.EarlyRenewalHours.ValueInt64()) * time.Hour)
+	if time.Now().Before(renewAt) {
+		return
+	}
+
+	plan.ID = types.StringUnknown()
+	plan.CertPEM = types.StringUnknown()
+	plan.PrivateKeyPEM = types.StringUnknown()
+	plan.PrivateKeyPKCS8PEM = types.StringUnknown()
+	plan.ValidityStartTime = types.StringUnknown()
+	plan.ValidityEndTime = types.StringUnknown()
+
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("validity_end_time"))
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// Create a new resource
+func (r *resourceClientCertificate) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ClientCertificate
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var tenantScope []int64
+	diags = plan.TenantScope.ElementsAs(ctx, &tenantScope, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cert, err := utils.IssueClientCertificate(utils.ClientCertificateRequest{
+		Username:     plan.Username.ValueString(),
+		KeyAlgorithm: plan.KeyAlgorithm.ValueString(),
+		RSABits:      int(plan.RSABits.ValueInt64()),
+		Validity:     time.Duration(plan.ValidityPeriodHours.ValueInt64()) * time.Hour,
+		TenantScope:  tenantScope,
+	}, plan.CACertPEM.ValueString(), plan.CAKeyPEM.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client certificate error",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(cert.SerialNumber.String())
+	plan.CertPEM = types.StringValue(cert.CertPEM)
+	plan.PrivateKeyPEM = types.StringValue(cert.KeyPEM)
+	plan.PrivateKeyPKCS8PEM = types.StringValue(cert.KeyPKCS8PEM)
+	plan.ValidityStartTime = types.StringValue(cert.NotBefore.Format(time.RFC3339))
+	plan.ValidityEndTime = types.StringValue(cert.NotAfter.Format(time.RFC3339))
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information. The certificate only exists in state, so there
+// is nothing to refresh.
+func (r *resourceClientCertificate) Read(_ context.Context, _ resource.ReadRequest, _ *resource.ReadResponse) {
+}
+
+// Update resource. Only early_renewal_hours can change in place.
+func (r *resourceClientCertificate) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan ClientCertificate
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete resource. Removing it from state is all there is to do.
+func (r *resourceClientCertificate) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
+
+type ClientCertificate struct {
+	ID                  types.String `tfsdk:"id"`
+	Username            types.String `tfsdk:"username"`
+	CACertPEM           types.String `tfsdk:"ca_cert_pem"`
+	CAKeyPEM            types.String `tfsdk:"ca_key_pem"`
+	KeyAlgorithm        types.String `tfsdk:"key_algorithm"`
+	RSABits             types.Int64  `tfsdk:"rsa_bits"`
+	ValidityPeriodHours types.Int64  `tfsdk:"validity_period_hours"`
+	EarlyRenewalHours   types.Int64  `tfsdk:"early_renewal_hours"`
+	TenantScope         types.List   `tfsdk:"tenant_scope"`
+	CertPEM             types.String `tfsdk:"cert_pem"`
+	PrivateKeyPEM       types.String `tfsdk:"private_key_pem"`
+	PrivateKeyPKCS8PEM  types.String `tfsdk:"private_key_pkcs8_pem"`
+	ValidityStartTime   types.String `tfsdk:"validity_start_time"`
+	ValidityEndTime     types.String `tfsdk:"validity_end_time"`
+}
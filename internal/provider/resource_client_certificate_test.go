@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"telusag/terraform-provider-cockroachdb/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccClientCertificateResource(t *testing.T) {
+	caCertPEM, caKeyPEM := createTestCA(t)
+
+	config := func(earlyRenewalHours int) string {
+		return prefixProvider(fmt.Sprintf(`
+resource "cockroachdb_client_certificate" "test_cert" {
+  username            = "cert_user"
+  ca_cert_pem         = <<EOT
+%sEOT
+  ca_key_pem          = <<EOT
+%sEOT
+  key_algorithm       = "ECDSA"
+  early_renewal_hours = %d
+}
+`, caCertPEM, caKeyPEM, earlyRenewalHours))
+	}
+
+	var serial string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: config(0),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cockroachdb_client_certificate.test_cert", "validity_period_hours", "43800"),
+					resource.TestCheckResourceAttr("cockroachdb_client_certificate.test_cert", "tenant_scope.0", "1"),
+					resource.TestCheckResourceAttrSet("cockroachdb_client_certificate.test_cert", "private_key_pem"),
+					resource.TestCheckResourceAttrWith("cockroachdb_client_certificate.test_cert", "cert_pem", func(value string) error {
+						cert, err := utils.ParseCertificatePEM(value)
+						if err != nil {
+							return err
+						}
+						if cert.Subject.CommonName != "cert_user" {
+							return fmt.Errorf("expected common name cert_user, got %s", cert.Subject.CommonName)
+						}
+						if len(cert.URIs) != 1 || cert.URIs[0].String() != "crdb://tenant/1/user/cert_user" {
+							return fmt.Errorf("unexpected URI SANs %v", cert.URIs)
+						}
+						serial = cert.SerialNumber.String()
+						return nil
+					}),
+				),
+			},
+			// Renewal testing, the window covers the whole validity period
+			{
+				Config: config(43800),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("cockroachdb_client_certificate.test_cert", "id", func(value string) error {
+						if value == serial {
+							return fmt.Errorf("expected the certificate to be re-issued")
+						}
+						return nil
+					}),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func createTestCA(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{Organization: []string{"Cockroach"}, CommonName: "Cockroach CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(10 * 365 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}))
+}
@@ -11,6 +11,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jackc/pgx/v5"
+	"github.com/lib/pq"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -47,11 +51,35 @@ func (r *resourceDatabase) Schema(_ context.Context, _ resource.SchemaRequest, r
 				Description: "Owner of the database",
 				Optional:    true,
 			},
+			"session_defaults": schema.MapAttribute{
+				Description: "Default session variables for every session in the database, e.g. `{ statement_timeout = \"30s\" }`",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
 			"id": schema.StringAttribute{
 				Description: "ID of the database",
 				Computed:    true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"backup_before_destroy": schema.SingleNestedBlock{
+				Description: "Back up the database before it is dropped. The destroy fails if the backup does not succeed. " +
+					"The block must already be applied to state before the destroy is planned.",
+				Attributes: map[string]schema.Attribute{
+					"destination": schema.StringAttribute{
+						Description: "Backup collection URI, e.g. `nodelocal://1/backups` or `external://my_connection`",
+						Required:    true,
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+					},
+					"revision_history": schema.BoolAttribute{
+						Description: "Include revision history in the backup. Default value is false.",
+						Optional:    true,
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -63,6 +91,86 @@ func (r *resourceDatabase) Configure(_ context.Context, req resource.ConfigureRe
 	r.p = req.ProviderData.(*cockroachdbProvider)
 }
 
+// setDatabaseSessionDefaults sets every session default in plan that differs
+// from state and resets the ones that were removed from plan
+func setDatabaseSessionDefaults(ctx context.Context, conn dbExecutor, database string, plan types.Map, state types.Map) error {
+	planDefaults := map[string]string{}
+	plan.ElementsAs(ctx, &planDefaults, false)
+
+	stateDefaults := map[string]string{}
+	state.ElementsAs(ctx, &stateDefaults, false)
+
+	queries := []string{}
+	for variable, value := range planDefaults {
+		if current, ok := stateDefaults[variable]; !ok || current != value {
+			queries = append(queries, fmt.Sprintf(
+				"ALTER DATABASE %s SET %s = %s",
+				pq.QuoteIdentifier(database),
+				pq.QuoteIdentifier(variable),
+				pq.QuoteLiteral(value),
+			))
+		}
+	}
+	for variable := range stateDefaults {
+		if _, ok := planDefaults[variable]; !ok {
+			queries = append(queries, fmt.Sprintf(
+				"ALTER DATABASE %s RESET %s",
+				pq.QuoteIdentifier(database),
+				pq.QuoteIdentifier(variable),
+			))
+		}
+	}
+
+	for _, query := range queries {
+		tflog.Info(ctx, query)
+
+		if _, err := conn.Exec(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// backupDatabase runs a detached `BACKUP DATABASE ... INTO` and waits for the
+// job to finish, returning an error unless it succeeded
+func backupDatabase(ctx context.Context, conn *pgx.Conn, database string, backup DatabaseBackup) error {
+	query := fmt.Sprintf(
+		"BACKUP DATABASE %s INTO %s WITH detached",
+		pq.QuoteIdentifier(database),
+		pq.QuoteLiteral(backup.Destination.ValueString()),
+	)
+	if backup.RevisionHistory.ValueBool() {
+		query += ", revision_history"
+	}
+
+	tflog.Info(ctx, query)
+
+	var jobID int64
+	err := conn.QueryRow(ctx, query).Scan(&jobID)
+	if err != nil {
+		return err
+	}
+
+	var (
+		status   string
+		jobError string
+	)
+	err = conn.QueryRow(ctx, fmt.Sprintf(`SELECT status, COALESCE(error, '') FROM [SHOW JOB WHEN COMPLETE %d]`, jobID)).Scan(
+		&status,
+		&jobError,
+	)
+	if err != nil {
+		return err
+	}
+
+	if status != "succeeded" {
+		return fmt.Errorf("backup job %d of database %s finished with status %s: %s", jobID, database, status, jobError)
+	}
+
+	return nil
+}
+
 // Create a new resource
 func (r *resourceDatabase) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan Database
@@ -83,12 +191,16 @@ func (r *resourceDatabase) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	// Execute SQL
+	// Schema changes are not run in an explicit transaction, each statement
+	// runs on its own and is retried by CockroachDB
 	if plan.Owner.ValueString() == "" {
 		_, err = conn.Exec(ctx, fmt.Sprintf(`CREATE DATABASE %s`, plan.Name.ValueString()))
 	} else {
 		_, err = conn.Exec(ctx, fmt.Sprintf(`CREATE DATABASE %s OWNER %s`, plan.Name.ValueString(), plan.Owner.ValueString()))
 	}
+	if err == nil {
+		err = setDatabaseSessionDefaults(ctx, conn, plan.Name.ValueString(), plan.SessionDefaults, types.MapNull(types.StringType))
+	}
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -161,6 +273,24 @@ func (r *resourceDatabase) Read(ctx context.Context, req resource.ReadRequest, r
 	state.Name = types.StringValue(name)
 	state.Owner = types.StringValue(owner)
 
+	// Session defaults are only managed when they are configured
+	if !state.SessionDefaults.IsNull() {
+		sessionDefaults, err := readDbRoleSettings(ctx, conn, "", name)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Cockroach execute sql error",
+				err.Error(),
+			)
+			return
+		}
+
+		state.SessionDefaults, diags = types.MapValueFrom(ctx, types.StringType, sessionDefaults)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// Set state
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -198,35 +328,32 @@ func (r *resourceDatabase) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	// Schema changes are not run in an explicit transaction, each statement
+	// runs on its own and is retried by CockroachDB
 	if stateDb.Name.ValueString() != planDb.Name.ValueString() {
-		// Update database
-		_, err := conn.Exec(ctx, fmt.Sprintf(`ALTER DATABASE %s RENAME TO %s`, stateDb.Name.ValueString(), planDb.Name.ValueString()))
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Cockroach connection error",
-				err.Error(),
-			)
-			return
-		}
-
-		// Update state
-		stateDb.Name = types.StringValue(planDb.Name.ValueString())
+		_, err = conn.Exec(ctx, fmt.Sprintf(`ALTER DATABASE %s RENAME TO %s`, stateDb.Name.ValueString(), planDb.Name.ValueString()))
 	}
 
-	if stateDb.Owner.ValueString() != planDb.Owner.ValueString() {
-		// Update database
-		_, err := conn.Exec(ctx, fmt.Sprintf(`ALTER DATABASE %s OWNER TO %s`, stateDb.Name.ValueString(), planDb.Owner.ValueString()))
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Cockroach execute sql error",
-				err.Error(),
-			)
-			return
-		}
+	if err == nil && stateDb.Owner.ValueString() != planDb.Owner.ValueString() {
+		_, err = conn.Exec(ctx, fmt.Sprintf(`ALTER DATABASE %s OWNER TO %s`, planDb.Name.ValueString(), planDb.Owner.ValueString()))
+	}
 
-		// Update state
-		stateDb.Owner = types.StringValue(planDb.Owner.ValueString())
+	if err == nil {
+		err = setDatabaseSessionDefaults(ctx, conn, planDb.Name.ValueString(), planDb.SessionDefaults, stateDb.SessionDefaults)
 	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach execute sql error",
+			err.Error(),
+		)
+		return
+	}
+
+	// Update state
+	stateDb.Name = types.StringValue(planDb.Name.ValueString())
+	stateDb.Owner = types.StringValue(planDb.Owner.ValueString())
+	stateDb.SessionDefaults = planDb.SessionDefaults
+	stateDb.BackupBeforeDestroy = planDb.BackupBeforeDestroy
 
 	// Set state
 	diags = resp.State.Set(ctx, &stateDb)
@@ -256,6 +383,25 @@ func (r *resourceDatabase) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
+	// Back up the database first if requested
+	if !state.BackupBeforeDestroy.IsNull() {
+		var backup DatabaseBackup
+		diags = state.BackupBeforeDestroy.As(ctx, &backup, basetypes.ObjectAsOptions{})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		err = backupDatabase(ctx, conn, state.Name.ValueString(), backup)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Cockroach backup error",
+				err.Error(),
+			)
+			return
+		}
+	}
+
 	_, err = conn.Exec(ctx, fmt.Sprintf(`DROP DATABASE %s`, state.Name.ValueString()))
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -282,7 +428,14 @@ func (r *resourceDatabase) ImportState(ctx context.Context, req resource.ImportS
 }
 
 type Database struct {
-	ID    types.String `tfsdk:"id"`
-	Name  types.String `tfsdk:"name"`
-	Owner types.String `tfsdk:"owner"`
+	ID                  types.String `tfsdk:"id"`
+	Name                types.String `tfsdk:"name"`
+	Owner               types.String `tfsdk:"owner"`
+	SessionDefaults     types.Map    `tfsdk:"session_defaults"`
+	BackupBeforeDestroy types.Object `tfsdk:"backup_before_destroy"`
+}
+
+type DatabaseBackup struct {
+	Destination     types.String `tfsdk:"destination"`
+	RevisionHistory types.Bool   `tfsdk:"revision_history"`
 }
@@ -45,6 +45,55 @@ resource "cockroachdb_database" "test_database" {
 					resource.TestCheckResourceAttr("cockroachdb_database.test_database", "owner", "root"),
 				),
 			},
+			// Session defaults testing
+			{
+				Config: prefixProvider(`
+resource "cockroachdb_database" "test_database" {
+	name = "test_database_two"
+	owner = "root"
+	session_defaults = {
+		statement_timeout = "10s"
+		application_name  = "terraform"
+	}
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cockroachdb_database.test_database", "session_defaults.%", "2"),
+					resource.TestCheckResourceAttr("cockroachdb_database.test_database", "session_defaults.statement_timeout", "10s"),
+					resource.TestCheckResourceAttr("cockroachdb_database.test_database", "session_defaults.application_name", "terraform"),
+				),
+			},
+			{
+				Config: prefixProvider(`
+resource "cockroachdb_database" "test_database" {
+	name = "test_database_two"
+	owner = "root"
+	session_defaults = {
+		statement_timeout = "20s"
+	}
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cockroachdb_database.test_database", "session_defaults.%", "1"),
+					resource.TestCheckResourceAttr("cockroachdb_database.test_database", "session_defaults.statement_timeout", "20s"),
+				),
+			},
+			// Backup before destroy testing
+			{
+				Config: prefixProvider(`
+resource "cockroachdb_database" "test_database" {
+	name = "test_database_two"
+	owner = "root"
+
+	backup_before_destroy {
+		destination = "nodelocal://1/test_database_two"
+	}
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cockroachdb_database.test_database", "backup_before_destroy.destination", "nodelocal://1/test_database_two"),
+				),
+			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
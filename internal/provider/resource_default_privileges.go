@@ -0,0 +1,533 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"telusag/terraform-provider-cockroachdb/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jackc/pgx/v5"
+	"github.com/lib/pq"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &resourceDefaultPrivileges{}
+	_ resource.ResourceWithConfigure      = &resourceDefaultPrivileges{}
+	_ resource.ResourceWithImportState    = &resourceDefaultPrivileges{}
+	_ resource.ResourceWithValidateConfig = &resourceDefaultPrivileges{}
+)
+
+// allRolesTarget stands for FOR ALL ROLES in the ID of default privileges
+const allRolesTarget = "*"
+
+func NewDefaultPrivilegesResource() resource.Resource {
+	return &resourceDefaultPrivileges{}
+}
+
+type resourceDefaultPrivileges struct {
+	p *cockroachdbProvider
+}
+
+func (r *resourceDefaultPrivileges) Metadata(_ context.Context, _ resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = "cockroachdb_default_privileges"
+}
+
+func (r *resourceDefaultPrivileges) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the privileges a role is granted on objects created in the future (`ALTER DEFAULT PRIVILEGES`).",
+		Attributes: map[string]schema.Attribute{
+			"role": schema.StringAttribute{
+				Description: "Role the privileges are granted to",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"database": schema.StringAttribute{
+				Description: "Target database name",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"schema": schema.StringAttribute{
+				Description: "Only apply to objects created in this schema. Cannot be used when `object_type` is `schema`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"target_role": schema.StringAttribute{
+				Description: "Only apply to objects created by this role. Defaults to the role the provider connects as.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ConflictsWith(path.MatchRoot("for_all_roles")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"for_all_roles": schema.BoolAttribute{
+				Description: "Apply to objects created by any role. Default value is false.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"object_type": schema.StringAttribute{
+				Description: "Object type. Must be one of the following:  table, sequence, type, schema, or function",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^(table|sequence|type|schema|function)$`), "Value must match RegExp: ^(table|sequence|type|schema|function)$"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"privileges": schema.SetAttribute{
+				Description: "Privileges to grant. Names are compared case-insensitively and `ALL` is equivalent to listing every privilege of the object type.",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"with_grant_option": schema.BoolAttribute{
+				Description: "Allows the role to grant the privileges to other roles. Default value is false.",
+				Optional:    true,
+			},
+			"id": schema.StringAttribute{
+				Description: "ID of the default privileges, `role|database|schema|target_role|object_type` where `target_role` is `*` for all roles",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *resourceDefaultPrivileges) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.p = req.ProviderData.(*cockroachdbProvider)
+}
+
+// ValidateConfig checks the privileges against the object type
+func (r *resourceDefaultPrivileges) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config DefaultPrivileges
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.ObjectType.IsUnknown() {
+		return
+	}
+
+	if config.ObjectType.ValueString() == "schema" && !config.Schema.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("schema"),
+			"Invalid default privileges",
+			"Cannot specify `schema` when `object_type` is `schema`",
+		)
+	}
+
+	if config.Privileges.IsUnknown() {
+		return
+	}
+
+	privileges := []string{}
+	config.Privileges.ElementsAs(ctx, &privileges, false)
+	if err := utils.ValidatePrivileges(ctx, config.ObjectType.ValueString(), privileges); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("privileges"),
+			"Invalid default privileges",
+			err.Error(),
+		)
+	}
+}
+
+// getDefaultPrivilegesScope returns the `FOR ROLE` and `IN SCHEMA` clauses
+// shared by ALTER and SHOW DEFAULT PRIVILEGES
+func getDefaultPrivilegesScope(defaultPrivileges DefaultPrivileges) string {
+	var scope string
+
+	if defaultPrivileges.ForAllRoles.ValueBool() {
+		scope += " FOR ALL ROLES"
+	} else if defaultPrivileges.TargetRole.ValueString() != "" {
+		scope += fmt.Sprintf(" FOR ROLE %s", pq.QuoteIdentifier(defaultPrivileges.TargetRole.ValueString()))
+	}
+
+	if defaultPrivileges.Schema.ValueString() != "" {
+		scope += fmt.Sprintf(" IN SCHEMA %s", pq.QuoteIdentifier(defaultPrivileges.Schema.ValueString()))
+	}
+
+	return scope
+}
+
+func getDefaultPrivilegesID(defaultPrivileges DefaultPrivileges) types.String {
+	target := defaultPrivileges.TargetRole.ValueString()
+	if defaultPrivileges.ForAllRoles.ValueBool() {
+		target = allRolesTarget
+	}
+
+	return types.StringValue(strings.Join([]string{
+		defaultPrivileges.Role.ValueString(),
+		defaultPrivileges.Database.ValueString(),
+		defaultPrivileges.Schema.ValueString(),
+		target,
+		defaultPrivileges.ObjectType.ValueString(),
+	}, "|"))
+}
+
+func readDefaultPrivileges(ctx context.Context, conn dbExecutor, defaultPrivileges *DefaultPrivileges) error {
+	var (
+		privilegeType string
+		isGrantable   bool
+	)
+
+	objectType := defaultPrivileges.ObjectType.ValueString()
+	privileges := []string{}
+	grantable := true
+
+	// The server reports object types in the plural, and functions as routines
+	rows, err := conn.Query(ctx,
+		fmt.Sprintf(
+			"SELECT privilege_type, is_grantable FROM [SHOW DEFAULT PRIVILEGES%s] WHERE grantee = $1 AND lower(object_type) IN ($2, $3)",
+			getDefaultPrivilegesScope(*defaultPrivileges),
+		),
+		defaultPrivileges.Role.ValueString(),
+		objectType+"s",
+		strings.Replace(objectType, "function", "routine", 1)+"s",
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = pgx.ForEachRow(rows, []any{&privilegeType, &isGrantable}, func() error {
+		privileges = append(privileges, privilegeType)
+		// The grant option only holds if every privilege can be granted on
+		grantable = grantable && isGrantable
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Set privileges, spelled the way they already are
+	currentPrivileges := []string{}
+	defaultPrivileges.Privileges.ElementsAs(ctx, &currentPrivileges, false)
+	defaultPrivileges.Privileges, _ = types.SetValueFrom(ctx, types.StringType, normalizePrivileges(objectType, privileges, currentPrivileges))
+
+	// Only report the grant option if it's set or being managed
+	grantable = grantable && len(privileges) > 0
+	if grantable || !defaultPrivileges.WithGrantOption.IsNull() {
+		defaultPrivileges.WithGrantOption = types.BoolValue(grantable)
+	}
+
+	return nil
+}
+
+func grantDefaultPrivileges(ctx context.Context, conn dbExecutor, defaultPrivileges *DefaultPrivileges) error {
+	privileges := []string{}
+	defaultPrivileges.Privileges.ElementsAs(ctx, &privileges, false)
+
+	query := fmt.Sprintf(
+		"ALTER DEFAULT PRIVILEGES%s GRANT %s ON %sS TO %s",
+		getDefaultPrivilegesScope(*defaultPrivileges),
+		strings.Join(privileges, ","),
+		strings.ToUpper(defaultPrivileges.ObjectType.ValueString()),
+		pq.QuoteIdentifier(defaultPrivileges.Role.ValueString()),
+	)
+	if defaultPrivileges.WithGrantOption.ValueBool() {
+		query += " WITH GRANT OPTION"
+	}
+
+	tflog.Info(ctx, query)
+
+	_, err := conn.Exec(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	defaultPrivileges.ID = getDefaultPrivilegesID(*defaultPrivileges)
+
+	return nil
+}
+
+func revokeDefaultPrivileges(ctx context.Context, conn dbExecutor, defaultPrivileges DefaultPrivileges) error {
+	query := fmt.Sprintf(
+		"ALTER DEFAULT PRIVILEGES%s REVOKE ALL ON %sS FROM %s",
+		getDefaultPrivilegesScope(defaultPrivileges),
+		strings.ToUpper(defaultPrivileges.ObjectType.ValueString()),
+		pq.QuoteIdentifier(defaultPrivileges.Role.ValueString()),
+	)
+
+	tflog.Info(ctx, query)
+
+	_, err := conn.Exec(ctx, query)
+	return err
+}
+
+// Create a new resource
+func (r *resourceDefaultPrivileges) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan DefaultPrivileges
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Connecting to database '%s'", plan.Database.ValueString()))
+
+	// Connect to db
+	conn, err := r.p.Conn(ctx, plan.Database.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach connection error",
+			err.Error(),
+		)
+		return
+	}
+	defer conn.Close(ctx)
+
+	// Replace any existing default privileges so the role ends up with exactly the planned set
+	err = executeInTx(ctx, conn, func(tx pgx.Tx) error {
+		if err := revokeDefaultPrivileges(ctx, tx, plan); err != nil {
+			return err
+		}
+
+		return grantDefaultPrivileges(ctx, tx, &plan)
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach sql error",
+			err.Error(),
+		)
+		return
+	}
+
+	// Read back what was just set in DB
+	err = readDefaultPrivileges(ctx, conn, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach sql error",
+			err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information
+func (r *resourceDefaultPrivileges) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state DefaultPrivileges
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// In cases where we are importing state from a single ID, parse the ID into the proper pieces
+	if state.Role.IsNull() {
+		idPieces := strings.Split(state.ID.ValueString(), "|")
+		if len(idPieces) != 5 {
+			resp.Diagnostics.AddError(
+				"Invalid default privileges ID",
+				"Expected `role|database|schema|target_role|object_type`, got "+state.ID.ValueString(),
+			)
+			return
+		}
+
+		state.Role = types.StringValue(idPieces[0])
+		state.Database = types.StringValue(idPieces[1])
+		if idPieces[2] != "" {
+			state.Schema = types.StringValue(idPieces[2])
+		}
+		if idPieces[3] == allRolesTarget {
+			state.ForAllRoles = types.BoolValue(true)
+		} else if idPieces[3] != "" {
+			state.TargetRole = types.StringValue(idPieces[3])
+		}
+		state.ObjectType = types.StringValue(idPieces[4])
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Connecting to database '%s'", state.Database.ValueString()))
+
+	// Connect to db
+	conn, err := r.p.Conn(ctx, state.Database.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach connection error",
+			err.Error(),
+		)
+		return
+	}
+	defer conn.Close(ctx)
+
+	err = readDefaultPrivileges(ctx, conn, &state)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach sql error",
+			err.Error(),
+		)
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update resource
+func (r *resourceDefaultPrivileges) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var (
+		state DefaultPrivileges
+		plan  DefaultPrivileges
+	)
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Connecting to database '%s'", plan.Database.ValueString()))
+
+	// Connect to db
+	conn, err := r.p.Conn(ctx, plan.Database.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach connection error",
+			err.Error(),
+		)
+		return
+	}
+	defer conn.Close(ctx)
+
+	// A renamed role keeps its default privileges, so revoke them under its new name
+	state.Role, err = repointRenamedRole(ctx, conn, state.Role, plan.Role)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach sql error",
+			err.Error(),
+		)
+		return
+	}
+
+	// Remove the default privileges stored in state and add the planned ones in one transaction
+	err = executeInTx(ctx, conn, func(tx pgx.Tx) error {
+		if err := revokeDefaultPrivileges(ctx, tx, state); err != nil {
+			return err
+		}
+
+		return grantDefaultPrivileges(ctx, tx, &plan)
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach sql error",
+			err.Error(),
+		)
+		return
+	}
+
+	// Read back what was set in DB
+	err = readDefaultPrivileges(ctx, conn, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach sql error",
+			err.Error(),
+		)
+		return
+	}
+
+	// Update state with what was actually stored
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete resource
+func (r *resourceDefaultPrivileges) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state DefaultPrivileges
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Connecting to database '%s'", state.Database.ValueString()))
+
+	// Connect to db
+	conn, err := r.p.Conn(ctx, state.Database.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach connection error",
+			err.Error(),
+		)
+		return
+	}
+	defer conn.Close(ctx)
+
+	err = revokeDefaultPrivileges(ctx, conn, state)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach sql error",
+			err.Error(),
+		)
+		return
+	}
+}
+
+func (r *resourceDefaultPrivileges) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+type DefaultPrivileges struct {
+	ID              types.String `tfsdk:"id"`
+	Role            types.String `tfsdk:"role"`
+	Database        types.String `tfsdk:"database"`
+	Schema          types.String `tfsdk:"schema"`
+	TargetRole      types.String `tfsdk:"target_role"`
+	ForAllRoles     types.Bool   `tfsdk:"for_all_roles"`
+	ObjectType      types.String `tfsdk:"object_type"`
+	Privileges      types.Set    `tfsdk:"privileges"`
+	WithGrantOption types.Bool   `tfsdk:"with_grant_option"`
+}
@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDefaultPrivilegesResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: prefixProvider(`
+resource "cockroachdb_role" "test_role" {
+	name = "test_role"
+}
+
+resource "cockroachdb_default_privileges" "test_default_privileges" {
+	role          = cockroachdb_role.test_role.name
+	database      = "defaultdb"
+	schema        = "public"
+	for_all_roles = true
+	object_type   = "table"
+	privileges    = ["select"]
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cockroachdb_default_privileges.test_default_privileges", "id", "test_role|defaultdb|public|*|table"),
+					resource.TestCheckTypeSetElemAttr("cockroachdb_default_privileges.test_default_privileges", "privileges.*", "select"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "cockroachdb_default_privileges.test_default_privileges",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Import reads the server's spelling
+				ImportStateVerifyIgnore: []string{"privileges"},
+			},
+			// Update and Read testing
+			{
+				Config: prefixProvider(`
+resource "cockroachdb_role" "test_role" {
+	name = "test_role"
+}
+
+resource "cockroachdb_default_privileges" "test_default_privileges" {
+	role              = cockroachdb_role.test_role.name
+	database          = "defaultdb"
+	schema            = "public"
+	for_all_roles     = true
+	object_type       = "table"
+	privileges        = ["SELECT", "INSERT"]
+	with_grant_option = true
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cockroachdb_default_privileges.test_default_privileges", "privileges.#", "2"),
+					resource.TestCheckResourceAttr("cockroachdb_default_privileges.test_default_privileges", "with_grant_option", "true"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -2,21 +2,20 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
 	"telusag/terraform-provider-cockroachdb/internal/utils"
 
-	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
-	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/jackc/pgtype"
 	"github.com/jackc/pgx/v5"
 	"github.com/lib/pq"
 )
@@ -59,30 +58,34 @@ func (r *resourceGrant) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				},
 			},
 			"schema": schema.StringAttribute{
-				Description: "Target schema name",
+				Description: "Target schema name. Objects are in `public` when it is not set.",
 				Optional:    true,
 			},
 			"object_type": schema.StringAttribute{
-				Description: "Object type. Must be one of the following:  database, schema, or table",
+				Description: "Object type. Must be one of the following:  database, schema, table, sequence, type, function, procedure, or external_connection",
 				Required:    true,
 				Validators: []validator.String{
 					stringvalidator.LengthAtLeast(1),
-					stringvalidator.RegexMatches(regexp.MustCompile(`^(database|schema|table)$`), "Value must match RegExp: ^(database|schema|table)$"),
+					stringvalidator.RegexMatches(regexp.MustCompile(`^(database|schema|table|sequence|type|function|procedure|external_connection)$`), "Value must match RegExp: ^(database|schema|table|sequence|type|function|procedure|external_connection)$"),
 				},
 			},
-			"objects": schema.ListAttribute{
-				Description: "Objects to grant privileges on",
+			"objects": schema.SetAttribute{
+				Description: "Objects to grant privileges on, e.g. `tractor`, `public.tractor` or, for functions and procedures, a signature such as `area(INT, INT)`. Each part of a name is quoted, so it must be spelled exactly as the object is named. Leave empty to grant on all tables, sequences, functions or procedures in `schema`. Required for types and external connections.",
 				Optional:    true,
 				ElementType: types.StringType,
 			},
-			"privileges": schema.ListAttribute{
-				Description: "Privileges to grant",
+			"privileges": schema.SetAttribute{
+				Description: "Privileges to grant. Names are compared case-insensitively and `ALL` is equivalent to listing every privilege of the object type.",
 				Required:    true,
 				ElementType: types.StringType,
-				Validators: []validator.List{
-					listvalidator.SizeAtLeast(1),
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
 				},
 			},
+			"with_grant_option": schema.BoolAttribute{
+				Description: "Allows the role to grant the privileges to other roles. Default value is false.",
+				Optional:    true,
+			},
 			"id": schema.StringAttribute{
 				Description: "ID of the grant",
 				Computed:    true,
@@ -91,94 +94,410 @@ func (r *resourceGrant) Schema(_ context.Context, _ resource.SchemaRequest, resp
 	}
 }
 
-func readRolePrivileges(ctx context.Context, conn *pgx.Conn, grant *Grant) error {
+// objectPrivileges are the privileges a role holds on one object, or on the
+// database or schema itself
+type objectPrivileges struct {
+	name       string
+	privileges []string
+	grantable  bool
+}
+
+// readObjectPrivileges returns what the role holds on each object of the
+// grant's type, in the grant's database and schema. Without a schema the
+// objects are in `public`, except on import where the schema is taken from
+// the privileges.
+func readObjectPrivileges(ctx context.Context, conn dbExecutor, grant *Grant) ([]*objectPrivileges, error) {
 	dbName := grant.Database.ValueString()
 	role := grant.Role.ValueString()
-	objectType := grant.ObjectType.ValueString()
-	objects := []string{}
-	privileges := []string{}
+	objectType := strings.ToLower(grant.ObjectType.ValueString())
+	byKey := map[string]*objectPrivileges{}
+	held := []*objectPrivileges{}
+
+	schemaName := grant.Schema.ValueString()
+	inferSchema := schemaName == "" && grant.Privileges.IsNull()
+	if schemaName == "" {
+		schemaName = "public"
+	}
 
-	var (
-		err  error
-		rows pgx.Rows
-	)
+	sequences, err := listSequences(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
 
-	rows, err = conn.Query(ctx, fmt.Sprintf(`SHOW GRANTS FOR %s;`, role))
+	rows, err := conn.Query(ctx, fmt.Sprintf(`SHOW GRANTS FOR %s;`, role))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Loop through each returned row
-	for rows.Next() {
-		var (
-			database_name  pgtype.Text
-			schema_name    pgtype.Text
-			relation_name  pgtype.Text
-			grantee        pgtype.Text
-			privilege_type pgtype.Text
-			is_grantable   pgtype.Bool
-		)
+	// Older versions name the object `relation_name` and newer ones add
+	// `object_name` and `object_type`, so map the columns by name
+	grantRows, err := pgx.CollectRows(rows, pgx.RowToMap)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range grantRows {
+		databaseName := grantColumn(row, "database_name")
+		rowSchema := grantColumn(row, "schema_name")
+		objectName := grantColumn(row, "object_name", "relation_name")
+		privilegeType := grantColumn(row, "privilege_type")
+		isGrantable, _ := row["is_grantable"].(bool)
 
-		// Map row vals
-		if err := rows.Scan(&database_name, &schema_name, &relation_name, &grantee, &privilege_type, &is_grantable); err != nil {
-			tflog.Error(ctx, err.Error())
+		// See if this row pertains to the state
+		if !grantObjectTypeMatches(objectType, grantRowObjectType(row, sequences)) {
 			continue
 		}
 
-		// See if this row pertains to the state
-		switch strings.ToUpper(objectType) {
-		case "DATABASE":
-			// If schema_name is not NULL or relation_name is not NULL it's not a database privilege
-			if !utils.IsNilOrEmpty(&schema_name.String) || !utils.IsNilOrEmpty(&relation_name.String) || database_name.String != dbName {
-				continue
+		// External connections belong to the cluster, everything else to a database
+		if objectType != "external_connection" && databaseName != dbName {
+			continue
+		}
+
+		key := ""
+		switch objectType {
+		case "database":
+		case "external_connection":
+			key = objectKey(objectName)
+		default:
+			if inferSchema {
+				schemaName = rowSchema
+				grant.Schema = types.StringValue(rowSchema)
+				inferSchema = false
 			}
-		case "SCHEMA":
-			// If schema_name is NULL or relation_name is not NULL it's not a schema privilege
-			if utils.IsNilOrEmpty(&schema_name.String) || !utils.IsNilOrEmpty(&relation_name.String) {
+			if rowSchema != schemaName {
 				continue
 			}
+			if objectType != "schema" {
+				key = objectKey(objectName)
+			}
+		}
 
-			// Set schema
-			grant.Schema = types.StringValue(schema_name.String)
-		case "TABLE":
-			// If schema_name is NULL or relation_name is NULL it's not a table privilege
-			if utils.IsNilOrEmpty(&schema_name.String) || utils.IsNilOrEmpty(&relation_name.String) {
-				continue
+		object, ok := byKey[key]
+		if !ok {
+			object = &objectPrivileges{name: objectName, grantable: true}
+			byKey[key] = object
+			held = append(held, object)
+		}
+		if privilegeType != "" {
+			object.privileges = append(object.privileges, privilegeType)
+		}
+		// The grant option only holds if every privilege can be granted on
+		object.grantable = object.grantable && isGrantable
+	}
+
+	return held, nil
+}
+
+func readRolePrivileges(ctx context.Context, conn dbExecutor, grant *Grant) error {
+	held, err := readObjectPrivileges(ctx, conn, grant)
+	if err != nil {
+		return err
+	}
+
+	keep, err := keepGrantWithoutObjects(ctx, conn, *grant, held)
+	if err != nil || keep {
+		return err
+	}
+
+	reconcileGrant(ctx, grant, held, true)
+
+	return nil
+}
+
+// keepGrantWithoutObjects reports whether a grant on every object of a schema
+// holds nothing because the schema has no objects of its type yet. There is
+// nothing to compare then, so the grant is kept as it is in state.
+func keepGrantWithoutObjects(ctx context.Context, conn dbExecutor, grant Grant, held []*objectPrivileges) (bool, error) {
+	if len(held) > 0 || len(grant.Objects.Elements()) > 0 || grant.Privileges.IsNull() {
+		return false, nil
+	}
+
+	schemaName := grant.Schema.ValueString()
+	if schemaName == "" {
+		schemaName = "public"
+	}
+
+	relations := `SELECT EXISTS (SELECT 1 FROM pg_catalog.pg_class c JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace WHERE n.nspname = $1 AND c.relkind IN (%s))`
+	routines := `SELECT EXISTS (SELECT 1 FROM pg_catalog.pg_proc p JOIN pg_catalog.pg_namespace n ON n.oid = p.pronamespace WHERE n.nspname = $1 AND p.prokind = %s)`
+
+	var query string
+	switch strings.ToLower(grant.ObjectType.ValueString()) {
+	case "table":
+		query = fmt.Sprintf(relations, `'r', 'v', 'm'`)
+	case "sequence":
+		query = fmt.Sprintf(relations, `'S'`)
+	case "function":
+		query = fmt.Sprintf(routines, `'f'`)
+	case "procedure":
+		query = fmt.Sprintf(routines, `'p'`)
+	default:
+		// Databases and schemas always exist, everything else names its objects
+		return false, nil
+	}
+
+	var exists bool
+	if err := conn.QueryRow(ctx, query, schemaName).Scan(&exists); err != nil {
+		return false, err
+	}
+
+	return !exists, nil
+}
+
+// reconcileGrant sets the grant's objects, privileges and grant option from
+// what the role holds. An object is only reported when it holds exactly the
+// grant's privileges, or for grants that aren't authoritative at least them,
+// so drift on one object plans a change for just that object. When no object
+// does, the privileges every object has in common are reported instead, along
+// with any privilege an object holds beyond the grant's.
+func reconcileGrant(ctx context.Context, grant *Grant, held []*objectPrivileges, authoritative bool) {
+	objectType := strings.ToLower(grant.ObjectType.ValueString())
+	importing := grant.Privileges.IsNull()
+	configuredObjects := []string{}
+	grant.Objects.ElementsAs(ctx, &configuredObjects, false)
+	configuredPrivileges := []string{}
+	grant.Privileges.ElementsAs(ctx, &configuredPrivileges, false)
+	withGrantOption := grant.WithGrantOption.ValueBool()
+
+	// Without objects the grant covers everything held, the database or
+	// schema itself, or every object in the schema
+	targets := held
+	if len(configuredObjects) > 0 {
+		byKey := map[string]*objectPrivileges{}
+		for _, object := range held {
+			byKey[objectKey(object.name)] = object
+		}
+
+		targets = []*objectPrivileges{}
+		for _, name := range configuredObjects {
+			object, ok := byKey[objectKey(name)]
+			if !ok {
+				object = &objectPrivileges{}
 			}
+			targets = append(targets, &objectPrivileges{name: name, privileges: object.privileges, grantable: object.grantable})
+		}
+	}
 
-			// Set schema
-			grant.Schema = types.StringValue(schema_name.String)
+	// Compare each object's privileges with the grant's, spelled the way the grant has them
+	matching := []string{}
+	common := []string{}
+	extra := []string{}
+	grantable := len(targets) > 0
+	for i, object := range targets {
+		privileges := normalizePrivileges(objectType, object.privileges, configuredPrivileges)
+		if !authoritative {
+			privileges = heldOf(configuredPrivileges, privileges)
 		}
+		objectGrantable := object.grantable && len(privileges) > 0
 
-		// Set privileges and objects
-		if privilege_type.String != "" {
-			privileges = append(privileges, privilege_type.String)
+		// Grants that aren't authoritative don't mind a grant option they didn't ask for
+		grantOptionMatches := objectGrantable == withGrantOption || (!authoritative && !withGrantOption)
+		if sameFold(privileges, configuredPrivileges) && grantOptionMatches {
+			matching = append(matching, object.name)
 		}
-		if relation_name.String != "" {
-			objects = append(objects, relation_name.String)
+
+		if i == 0 {
+			common = privileges
+		} else {
+			common = intersectFold(common, privileges, strings.ToUpper)
+		}
+		extra = append(extra, differenceFold(privileges, configuredPrivileges, strings.ToUpper)...)
+		grantable = grantable && objectGrantable
+	}
+
+	// Privileges any object holds beyond the grant's have to be revoked too
+	common = keepSpelling(append(common, extra...), configuredPrivileges, strings.ToUpper)
+
+	objects := configuredObjects
+	if len(configuredObjects) > 0 && len(matching) > 0 {
+		objects = matching
+		common = configuredPrivileges
+		grantable = withGrantOption
+	} else if importing && objectType != "database" && objectType != "schema" {
+		// Import the objects the role holds privileges on
+		objects = []string{}
+		for _, object := range targets {
+			objects = append(objects, object.name)
 		}
 	}
 
-	// Set privileges on grant
-	var privilegesVals []attr.Value
-	for _, privilege := range privileges {
-		privilegesVals = append(privilegesVals, types.StringValue(privilege))
+	grant.Privileges, _ = types.SetValueFrom(ctx, types.StringType, common)
+	if len(objects) > 0 {
+		grant.Objects, _ = types.SetValueFrom(ctx, types.StringType, objects)
+	}
+
+	// Only report the grant option if it's set or being managed, and for grants
+	// that aren't authoritative only if it's managed
+	if (grantable && authoritative) || !grant.WithGrantOption.IsNull() {
+		grant.WithGrantOption = types.BoolValue(grantable)
+	}
+}
+
+// heldOf returns the wanted privileges that are among the held ones, of
+// which ALL holds every one
+func heldOf(wanted []string, held []string) []string {
+	if containsAllFold(held, []string{"ALL"}) {
+		return wanted
 	}
-	grant.Privileges, _ = types.ListValue(types.StringType, privilegesVals)
 
-	// Set objects on grant only if they exist
-	if len(objects) >= 1 {
-		var objectsVals []attr.Value
-		for _, object := range objects {
-			objectsVals = append(objectsVals, types.StringValue(object))
+	return intersectFold(wanted, held, strings.ToUpper)
+}
+
+// sameFold reports whether a and b hold the same values, ignoring case
+func sameFold(a []string, b []string) bool {
+	return len(differenceFold(a, b, strings.ToUpper)) == 0 && len(differenceFold(b, a, strings.ToUpper)) == 0
+}
+
+// grantColumn returns the first of the named columns present in a
+// `SHOW GRANTS` row, or "" when it's missing or NULL
+func grantColumn(row map[string]any, names ...string) string {
+	for _, name := range names {
+		if value, ok := row[name]; ok {
+			str, _ := value.(string)
+			return str
 		}
-		grant.Objects, _ = types.ListValue(types.StringType, objectsVals)
 	}
 
-	return nil
+	return ""
+}
+
+// grantRowObjectType works out the type of object a `SHOW GRANTS` row is for.
+// Versions without an `object_type` column only report database, schema and
+// relation privileges, and every version reports sequences as relations.
+func grantRowObjectType(row map[string]any, sequences map[string]bool) string {
+	objectType := strings.ReplaceAll(strings.ToLower(grantColumn(row, "object_type")), " ", "_")
+	relation := grantColumn(row, "object_name", "relation_name")
+
+	if _, ok := row["object_type"]; !ok {
+		switch {
+		case strings.TrimSpace(grantColumn(row, "schema_name")) == "":
+			objectType = "database"
+		case strings.TrimSpace(relation) == "":
+			objectType = "schema"
+		default:
+			objectType = "table"
+		}
+	}
+
+	if objectType == "table" && sequences[strings.ToLower(relation)] {
+		objectType = "sequence"
+	}
+
+	return objectType
+}
+
+// grantObjectTypeMatches compares the object_type of a grant with the one
+// reported by the server, which calls both functions and procedures routines
+func grantObjectTypeMatches(objectType string, reported string) bool {
+	if reported == "routine" {
+		return objectType == "function" || objectType == "procedure"
+	}
+
+	return objectType == reported
+}
+
+// listSequences returns the lowercased names of the sequences in the
+// connected database
+func listSequences(ctx context.Context, conn dbExecutor) (map[string]bool, error) {
+	rows, err := conn.Query(ctx, `SELECT relname FROM pg_catalog.pg_class WHERE relkind = 'S'`)
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, err
+	}
+
+	sequences := map[string]bool{}
+	for _, name := range names {
+		sequences[strings.ToLower(name)] = true
+	}
+
+	return sequences, nil
+}
+
+// keepSpelling de-duplicates the values reported by the server and replaces
+// each with its spelling in current when both have the same key
+func keepSpelling(reported []string, current []string, key func(string) string) []string {
+	spelling := map[string]string{}
+	for _, value := range current {
+		spelling[key(value)] = value
+	}
+
+	seen := map[string]bool{}
+	values := []string{}
+	for _, value := range reported {
+		k := key(value)
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+
+		if currentValue, ok := spelling[k]; ok {
+			value = currentValue
+		}
+		values = append(values, value)
+	}
+
+	return values
+}
+
+// objectKey compares objects case-insensitively and without their schema or
+// routine signature, as the server reports bare object names
+func objectKey(object string) string {
+	object, _, _ = strings.Cut(object, "(")
+	pieces := strings.Split(object, ".")
+	return strings.ToLower(pieces[len(pieces)-1])
+}
+
+// containsAllFold reports whether haystack contains every needle, ignoring case
+func containsAllFold(haystack []string, needles []string) bool {
+	for _, needle := range needles {
+		found := false
+		for _, s := range haystack {
+			if strings.EqualFold(s, needle) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// normalizePrivileges compares privileges case-insensitively and treats ALL
+// and its expanded form as equivalent, keeping whichever form current uses
+func normalizePrivileges(objectType string, reported []string, current []string) []string {
+	all := []string{"ALL"}
+	expanded := utils.ExpandAllPrivileges(strings.ToLower(objectType))
+
+	if containsAllFold(current, all) && !containsAllFold(reported, all) && containsAllFold(reported, expanded) {
+		// Every privilege was granted one by one, which is ALL
+		normalized := all
+		for _, privilege := range reported {
+			if !containsAllFold(expanded, []string{privilege}) {
+				normalized = append(normalized, privilege)
+			}
+		}
+		reported = normalized
+	} else if containsAllFold(reported, all) && !containsAllFold(current, all) && containsAllFold(current, expanded) {
+		// ALL was granted, which includes every privilege listed
+		normalized := expanded
+		for _, privilege := range reported {
+			if !strings.EqualFold(privilege, "ALL") {
+				normalized = append(normalized, privilege)
+			}
+		}
+		reported = normalized
+	}
+
+	return keepSpelling(reported, current, strings.ToUpper)
 }
 
-func grantRolePrivileges(ctx context.Context, conn *pgx.Conn, grant *Grant) error {
+func grantRolePrivileges(ctx context.Context, conn dbExecutor, grant *Grant) error {
 	var err error
 
 	query := getGrantQuery(ctx, grant)
@@ -191,99 +510,132 @@ func grantRolePrivileges(ctx context.Context, conn *pgx.Conn, grant *Grant) erro
 	}
 
 	// Generate "ID" from grant
-	grant.ID = types.StringValue(grant.Role.ValueString() + "|" + grant.Database.ValueString() + "|" + grant.ObjectType.ValueString())
+	grant.ID = getGrantID(*grant)
 
 	return nil
 }
 
-func getGrantQuery(ctx context.Context, grant *Grant) string {
-	var query string
+func getGrantID(grant Grant) types.String {
+	return types.StringValue(grant.Role.ValueString() + "|" + grant.Database.ValueString() + "|" + grant.ObjectType.ValueString())
+}
 
-	objects := []string{}
-	grant.Objects.ElementsAs(ctx, &objects, false)
+// quoteGrantObject quotes each part of an object name, qualifying it with
+// the grant's schema when it has none, e.g. `tractor` becomes
+// `"public"."tractor"`. The argument types of a routine signature, e.g.
+// `area(INT, INT)`, are kept as they are, and `*` stands for every table.
+func quoteGrantObject(schemaName string, object string) string {
+	if object == "*" {
+		return object
+	}
 
-	privileges := []string{}
-	grant.Privileges.ElementsAs(ctx, &privileges, false)
+	name, args, isRoutine := strings.Cut(object, "(")
+	parts := strings.Split(strings.TrimSpace(name), ".")
+	if len(parts) == 1 && schemaName != "" {
+		parts = append([]string{schemaName}, parts...)
+	}
+	for i, part := range parts {
+		parts[i] = pq.QuoteIdentifier(part)
+	}
 
-	switch strings.ToUpper(grant.ObjectType.ValueString()) {
-	case "DATABASE":
-		query = fmt.Sprintf(
-			"GRANT %s ON DATABASE %s TO %s",
-			strings.Join(privileges, ","),
-			pq.QuoteIdentifier(grant.Database.ValueString()),
-			pq.QuoteIdentifier(grant.Role.ValueString()),
-		)
-	case "SCHEMA":
-		query = fmt.Sprintf(
-			"GRANT %s ON SCHEMA %s TO %s",
-			strings.Join(privileges, ","),
-			pq.QuoteIdentifier(grant.Schema.ValueString()),
-			pq.QuoteIdentifier(grant.Role.ValueString()),
-		)
-	case "TABLE":
-		if len(objects) > 0 {
-			query = fmt.Sprintf(
-				"GRANT %s ON %s %s TO %s",
-				strings.Join(privileges, ","),
-				strings.ToUpper(strings.ToUpper(grant.ObjectType.ValueString())),
-				strings.Join(objects, " "),
-				pq.QuoteIdentifier(grant.Role.ValueString()),
-			)
-		} else {
-			query = fmt.Sprintf(
-				"GRANT %s ON ALL %sS IN SCHEMA %s TO %s",
-				strings.Join(privileges, ","),
-				strings.ToUpper(strings.ToUpper(grant.ObjectType.ValueString())),
-				pq.QuoteIdentifier(grant.Schema.ValueString()),
-				pq.QuoteIdentifier(grant.Role.ValueString()),
-			)
-		}
+	quoted := strings.Join(parts, ".")
+	if isRoutine {
+		quoted += "(" + args
 	}
 
-	return query
+	return quoted
 }
 
-func getRevokeQuery(ctx context.Context, grant Grant) string {
-	var query string
+// getGrantTarget returns the objects part of a GRANT or REVOKE statement,
+// e.g. `DATABASE "db"` or `ALL TABLES IN SCHEMA "public"`
+func getGrantTarget(ctx context.Context, grant Grant) string {
+	var target string
 
 	objects := []string{}
 	grant.Objects.ElementsAs(ctx, &objects, false)
 
+	quoted := []string{}
+	for _, object := range objects {
+		if strings.EqualFold(grant.ObjectType.ValueString(), "external_connection") {
+			// External connections belong to the cluster, not to a schema
+			quoted = append(quoted, pq.QuoteIdentifier(object))
+		} else {
+			quoted = append(quoted, quoteGrantObject(grant.Schema.ValueString(), object))
+		}
+	}
+
 	switch strings.ToUpper(grant.ObjectType.ValueString()) {
 	case "DATABASE":
-		query = fmt.Sprintf(
-			"REVOKE ALL PRIVILEGES ON DATABASE %s FROM %s",
-			pq.QuoteIdentifier(grant.Database.ValueString()),
-			pq.QuoteIdentifier(grant.Role.ValueString()),
-		)
+		target = fmt.Sprintf("DATABASE %s", pq.QuoteIdentifier(grant.Database.ValueString()))
 	case "SCHEMA":
-		query = fmt.Sprintf(
-			"REVOKE ALL PRIVILEGES ON SCHEMA %s FROM %s",
-			pq.QuoteIdentifier(grant.Schema.ValueString()),
-			pq.QuoteIdentifier(grant.Role.ValueString()),
-		)
-	case "TABLE":
+		target = fmt.Sprintf("SCHEMA %s", pq.QuoteIdentifier(grant.Schema.ValueString()))
+	case "TABLE", "SEQUENCE", "FUNCTION", "PROCEDURE":
+		objectType := strings.ToUpper(grant.ObjectType.ValueString())
 		if len(objects) > 0 {
-			query = fmt.Sprintf(
-				"REVOKE ALL PRIVILEGES ON %s %s FROM %s",
-				strings.ToUpper(strings.ToUpper(grant.ObjectType.ValueString())),
-				strings.Join(objects, " "),
-				pq.QuoteIdentifier(grant.Role.ValueString()),
-			)
+			target = fmt.Sprintf("%s %s", objectType, strings.Join(quoted, ", "))
 		} else {
-			query = fmt.Sprintf(
-				"REVOKE ALL PRIVILEGES ON ALL %sS IN SCHEMA %s FROM %s",
-				strings.ToUpper(strings.ToUpper(grant.ObjectType.ValueString())),
-				pq.QuoteIdentifier(grant.Schema.ValueString()),
-				pq.QuoteIdentifier(grant.Role.ValueString()),
-			)
+			target = fmt.Sprintf("ALL %sS IN SCHEMA %s", objectType, pq.QuoteIdentifier(grant.Schema.ValueString()))
 		}
+	case "TYPE":
+		target = fmt.Sprintf("TYPE %s", strings.Join(quoted, ", "))
+	case "EXTERNAL_CONNECTION":
+		target = fmt.Sprintf("EXTERNAL CONNECTION %s", strings.Join(quoted, ", "))
+	}
+
+	return target
+}
+
+func getGrantQuery(ctx context.Context, grant *Grant) string {
+	privileges := []string{}
+	grant.Privileges.ElementsAs(ctx, &privileges, false)
+
+	query := fmt.Sprintf(
+		"GRANT %s ON %s TO %s",
+		strings.Join(privileges, ","),
+		getGrantTarget(ctx, *grant),
+		pq.QuoteIdentifier(grant.Role.ValueString()),
+	)
+	if grant.WithGrantOption.ValueBool() {
+		query += " WITH GRANT OPTION"
 	}
 
 	return query
 }
 
-func revokeRolePrivileges(ctx context.Context, conn *pgx.Conn, grant *Grant) error {
+func getRevokeQuery(ctx context.Context, grant Grant) string {
+	return fmt.Sprintf(
+		"REVOKE ALL PRIVILEGES ON %s FROM %s",
+		getGrantTarget(ctx, grant),
+		pq.QuoteIdentifier(grant.Role.ValueString()),
+	)
+}
+
+func getRevokePrivilegesQuery(ctx context.Context, grant Grant) string {
+	privileges := []string{}
+	grant.Privileges.ElementsAs(ctx, &privileges, false)
+
+	return fmt.Sprintf(
+		"REVOKE %s ON %s FROM %s",
+		strings.Join(privileges, ","),
+		getGrantTarget(ctx, grant),
+		pq.QuoteIdentifier(grant.Role.ValueString()),
+	)
+}
+
+// getRevokeGrantOptionQuery takes away the role's ability to grant the
+// privileges to others, leaving the privileges themselves
+func getRevokeGrantOptionQuery(ctx context.Context, grant Grant) string {
+	privileges := []string{}
+	grant.Privileges.ElementsAs(ctx, &privileges, false)
+
+	return fmt.Sprintf(
+		"REVOKE GRANT OPTION FOR %s ON %s FROM %s",
+		strings.Join(privileges, ","),
+		getGrantTarget(ctx, grant),
+		pq.QuoteIdentifier(grant.Role.ValueString()),
+	)
+}
+
+func revokeRolePrivileges(ctx context.Context, conn dbExecutor, grant *Grant) error {
 	var err error
 
 	// Grab revoke query
@@ -303,6 +655,166 @@ func revokeRolePrivileges(ctx context.Context, conn *pgx.Conn, grant *Grant) err
 	return nil
 }
 
+// revokeExtraPrivileges revokes whatever the role holds on the grant's
+// target beyond the grant itself
+func revokeExtraPrivileges(ctx context.Context, conn dbExecutor, grant *Grant) error {
+	current := *grant
+	held, err := readObjectPrivileges(ctx, conn, &current)
+	if err != nil {
+		return err
+	}
+
+	objects := []string{}
+	grant.Objects.ElementsAs(ctx, &objects, false)
+	privileges := []string{}
+	grant.Privileges.ElementsAs(ctx, &privileges, false)
+
+	// Collect what any object of the target holds
+	heldPrivileges := []string{}
+	grantable := false
+	for _, object := range held {
+		if len(objects) > 0 && len(intersectFold(objects, []string{object.name}, objectKey)) == 0 {
+			continue
+		}
+		heldPrivileges = append(heldPrivileges, object.privileges...)
+		grantable = grantable || (object.grantable && len(object.privileges) > 0)
+	}
+
+	extra := differenceFold(normalizePrivileges(grant.ObjectType.ValueString(), heldPrivileges, privileges), privileges, strings.ToUpper)
+	if containsAllFold(extra, []string{"ALL"}) {
+		// Revoking ALL would take the granted privileges with it, so start over
+		if err := revokeRolePrivileges(ctx, conn, grant); err != nil {
+			return err
+		}
+
+		return grantRolePrivileges(ctx, conn, grant)
+	}
+
+	queries := []string{}
+	if len(extra) > 0 {
+		queries = append(queries, getRevokePrivilegesQuery(ctx, withPrivileges(ctx, *grant, nil, extra)))
+	}
+	if grantable && !grant.WithGrantOption.ValueBool() {
+		queries = append(queries, getRevokeGrantOptionQuery(ctx, *grant))
+	}
+
+	return execQueries(ctx, conn, queries)
+}
+
+// sameGrantTarget reports whether state and plan grant to the same role on
+// the same kind of target, so that the change can be applied as a diff
+func sameGrantTarget(ctx context.Context, state Grant, plan Grant) bool {
+	stateObjects := []string{}
+	state.Objects.ElementsAs(ctx, &stateObjects, false)
+	planObjects := []string{}
+	plan.Objects.ElementsAs(ctx, &planObjects, false)
+
+	return state.Role.ValueString() == plan.Role.ValueString() &&
+		state.Database.ValueString() == plan.Database.ValueString() &&
+		state.Schema.ValueString() == plan.Schema.ValueString() &&
+		strings.EqualFold(state.ObjectType.ValueString(), plan.ObjectType.ValueString()) &&
+		// Without objects the grant is on everything in the schema
+		(len(stateObjects) == 0) == (len(planObjects) == 0)
+}
+
+// getGrantDiffQueries returns the statements that take the role from the
+// grant in state to the planned one, leaving the privileges both have on
+// the objects both have untouched. Authoritative grants revoke everything on
+// objects that are no longer granted on, others only what they granted.
+func getGrantDiffQueries(ctx context.Context, state Grant, plan Grant, authoritative bool) []string {
+	stateObjects := []string{}
+	state.Objects.ElementsAs(ctx, &stateObjects, false)
+	planObjects := []string{}
+	plan.Objects.ElementsAs(ctx, &planObjects, false)
+	statePrivileges := []string{}
+	state.Privileges.ElementsAs(ctx, &statePrivileges, false)
+	planPrivileges := []string{}
+	plan.Privileges.ElementsAs(ctx, &planPrivileges, false)
+
+	removedObjects := differenceFold(stateObjects, planObjects, objectKey)
+	addedObjects := differenceFold(planObjects, stateObjects, objectKey)
+	keptObjects := intersectFold(planObjects, stateObjects, objectKey)
+	removedPrivileges := differenceFold(statePrivileges, planPrivileges, strings.ToUpper)
+	addedPrivileges := differenceFold(planPrivileges, statePrivileges, strings.ToUpper)
+
+	// Grants without objects are on the whole database, schema or every
+	// object in the schema, which is always kept
+	hasKept := len(planObjects) == 0 || len(keptObjects) > 0
+
+	queries := []string{}
+	if len(removedObjects) > 0 && authoritative {
+		queries = append(queries, getRevokeQuery(ctx, withPrivileges(ctx, state, removedObjects, statePrivileges)))
+	} else if len(removedObjects) > 0 {
+		queries = append(queries, getRevokePrivilegesQuery(ctx, withPrivileges(ctx, state, removedObjects, statePrivileges)))
+	}
+	if hasKept && len(removedPrivileges) > 0 {
+		// Revoke first, revoking ALL after granting a privilege would revoke that too
+		queries = append(queries, getRevokePrivilegesQuery(ctx, withPrivileges(ctx, plan, keptObjects, removedPrivileges)))
+	}
+	if hasKept && plan.WithGrantOption.ValueBool() && !state.WithGrantOption.ValueBool() {
+		queries = append(queries, getGrantQuery(ctx, utils.ToPtr(withPrivileges(ctx, plan, keptObjects, planPrivileges))))
+	} else if hasKept && len(addedPrivileges) > 0 {
+		queries = append(queries, getGrantQuery(ctx, utils.ToPtr(withPrivileges(ctx, plan, keptObjects, addedPrivileges))))
+	}
+	if hasKept && !plan.WithGrantOption.ValueBool() && state.WithGrantOption.ValueBool() {
+		queries = append(queries, getRevokeGrantOptionQuery(ctx, withPrivileges(ctx, plan, keptObjects, planPrivileges)))
+	}
+	if len(addedObjects) > 0 && authoritative {
+		// Added objects include ones that drifted, which may hold more than the grant
+		queries = append(queries, getRevokeQuery(ctx, withPrivileges(ctx, plan, addedObjects, planPrivileges)))
+	}
+	if len(addedObjects) > 0 {
+		queries = append(queries, getGrantQuery(ctx, utils.ToPtr(withPrivileges(ctx, plan, addedObjects, planPrivileges))))
+	}
+
+	return queries
+}
+
+// withPrivileges returns a copy of grant for only the given objects and
+// privileges
+func withPrivileges(ctx context.Context, grant Grant, objects []string, privileges []string) Grant {
+	if objects != nil {
+		grant.Objects, _ = types.SetValueFrom(ctx, types.StringType, objects)
+	}
+	grant.Privileges, _ = types.SetValueFrom(ctx, types.StringType, privileges)
+
+	return grant
+}
+
+// differenceFold returns the values in a that have no equivalent in b
+func differenceFold(a []string, b []string, key func(string) string) []string {
+	keys := map[string]bool{}
+	for _, value := range b {
+		keys[key(value)] = true
+	}
+
+	difference := []string{}
+	for _, value := range a {
+		if !keys[key(value)] {
+			difference = append(difference, value)
+		}
+	}
+
+	return difference
+}
+
+// intersectFold returns the values in a that have an equivalent in b
+func intersectFold(a []string, b []string, key func(string) string) []string {
+	return differenceFold(a, differenceFold(a, b, key), key)
+}
+
+func execQueries(ctx context.Context, conn dbExecutor, queries []string) error {
+	for _, query := range queries {
+		tflog.Info(ctx, query)
+
+		if _, err := conn.Exec(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Read resource information
 func (r resourceGrant) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var state Grant
@@ -356,6 +868,29 @@ func (r *resourceGrant) Configure(_ context.Context, req resource.ConfigureReque
 	r.p = req.ProviderData.(*cockroachdbProvider)
 }
 
+// validateGrant checks that the objects, schema and privileges of a grant
+// fit its object type
+func validateGrant(ctx context.Context, grant Grant) error {
+	objects := []string{}
+	grant.Objects.ElementsAs(ctx, &objects, false)
+	objectType := grant.ObjectType.ValueString()
+	if len(objects) > 0 && (objectType == "database" || objectType == "schema") {
+		return errors.New("Cannot specify `objects` when `object_type` is `database` or `schema`")
+	}
+
+	if (objectType == "database" || objectType == "external_connection") && grant.Schema.ValueString() != "" {
+		return fmt.Errorf("Cannot specify `schema` when `object_type` is `%s`", objectType)
+	}
+
+	if len(objects) == 0 && (objectType == "type" || objectType == "external_connection") {
+		return fmt.Errorf("Must specify `objects` when `object_type` is `%s`", objectType)
+	}
+
+	privileges := []string{}
+	grant.Privileges.ElementsAs(ctx, &privileges, false)
+	return utils.ValidatePrivileges(ctx, objectType, privileges)
+}
+
 // Create a new resource
 func (r resourceGrant) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan Grant
@@ -367,28 +902,7 @@ func (r resourceGrant) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 
 	// Validate params
-	objects := []string{}
-	plan.Objects.ElementsAs(ctx, &objects, false)
-	objectType := plan.ObjectType.ValueString()
-	if len(objects) > 0 && (objectType == "database" || objectType == "schema") {
-		resp.Diagnostics.AddError(
-			"Plan validation error",
-			"Cannot specify `objects` when `object_type` is `database` or `schema`",
-		)
-		return
-	}
-
-	if objectType == "database" && plan.Schema.ValueString() != "" {
-		resp.Diagnostics.AddError(
-			"Plan validation error",
-			"Cannot specify `schema` when `object_type` is `database`",
-		)
-		return
-	}
-
-	privileges := []string{}
-	plan.Privileges.ElementsAs(ctx, &privileges, false)
-	if err := utils.ValidatePrivileges(ctx, plan.ObjectType.ValueString(), privileges); err != nil {
+	if err := validateGrant(ctx, plan); err != nil {
 		resp.Diagnostics.AddError(
 			"Plan validation error",
 			err.Error(),
@@ -408,18 +922,15 @@ func (r resourceGrant) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	// Revoke any existing privileges because it will result in an error if they already exist
-	err = revokeRolePrivileges(ctx, conn, &plan)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Cockroach sql error",
-			err.Error(),
-		)
-		return
-	}
+	// Create the Grant, then revoke anything else the role already held on the
+	// target so privileges it keeps are never taken away in between
+	err = executeInTx(ctx, conn, func(tx pgx.Tx) error {
+		if err := grantRolePrivileges(ctx, tx, &plan); err != nil {
+			return err
+		}
 
-	// Create the Grant
-	err = grantRolePrivileges(ctx, conn, &plan)
+		return revokeExtraPrivileges(ctx, tx, &plan)
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Cockroach sql error",
@@ -447,58 +958,77 @@ func (r resourceGrant) Create(ctx context.Context, req resource.CreateRequest, r
 
 // Update resource
 func (r resourceGrant) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// Remove any grants that are stored in state (may or may not be in there)
-	var state Grant
+	var (
+		state Grant
+		plan  Grant
+	)
+
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	tflog.Info(ctx, fmt.Sprintf("Connecting to database '%s'", state.Database.ValueString()))
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	// Connect to db
-	conn, err := r.p.Conn(ctx, state.Database.ValueString())
-	if err != nil {
+	// Validate params
+	if err := validateGrant(ctx, plan); err != nil {
 		resp.Diagnostics.AddError(
-			"Cockroach connection error",
+			"Plan validation error",
 			err.Error(),
 		)
 		return
 	}
 
-	// Delete Grant
-	err = revokeRolePrivileges(ctx, conn, &state)
+	tflog.Info(ctx, fmt.Sprintf("Connecting to database '%s'", plan.Database.ValueString()))
+
+	// Connect to db
+	conn, err := r.p.Conn(ctx, plan.Database.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Cockroach sql error",
+			"Cockroach connection error",
 			err.Error(),
 		)
 		return
 	}
 
-	// Add any plans
-	var plan Grant
-	diags = req.Plan.Get(ctx, &plan)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	tflog.Info(ctx, fmt.Sprintf("Connecting to database '%s'", plan.Database.ValueString()))
-
-	// Connect to db
-	conn, err = r.p.Conn(ctx, plan.Database.ValueString())
+	// A renamed role keeps its privileges, so revoke them under its new name
+	state.Role, err = repointRenamedRole(ctx, conn, state.Role, plan.Role)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Cockroach connection error",
+			"Cockroach sql error",
 			err.Error(),
 		)
 		return
 	}
 
-	// Create the Grant
-	err = grantRolePrivileges(ctx, conn, &plan)
+	if sameGrantTarget(ctx, state, plan) {
+		// Only grant and revoke what changed, in one transaction
+		err = executeInTx(ctx, conn, func(tx pgx.Tx) error {
+			return execQueries(ctx, tx, getGrantDiffQueries(ctx, state, plan, true))
+		})
+		plan.ID = getGrantID(plan)
+	} else if state.Database.ValueString() == plan.Database.ValueString() {
+		// Remove the grants stored in state and add the planned ones in one transaction
+		err = executeInTx(ctx, conn, func(tx pgx.Tx) error {
+			if err := revokeRolePrivileges(ctx, tx, &state); err != nil {
+				return err
+			}
+
+			return grantRolePrivileges(ctx, tx, &plan)
+		})
+	} else {
+		// Object names are resolved against the connected database, so when the
+		// database changes the revoke has to run on its own connection first
+		err = r.revokeFromDatabase(ctx, &state)
+		if err == nil {
+			err = grantRolePrivileges(ctx, conn, &plan)
+		}
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Cockroach sql error",
@@ -525,6 +1055,19 @@ func (r resourceGrant) Update(ctx context.Context, req resource.UpdateRequest, r
 	}
 }
 
+// revokeFromDatabase revokes the grant on a connection to its own database
+func (r resourceGrant) revokeFromDatabase(ctx context.Context, grant *Grant) error {
+	tflog.Info(ctx, fmt.Sprintf("Connecting to database '%s'", grant.Database.ValueString()))
+
+	conn, err := r.p.Conn(ctx, grant.Database.ValueString())
+	if err != nil {
+		return err
+	}
+	defer conn.Close(ctx)
+
+	return revokeRolePrivileges(ctx, conn, grant)
+}
+
 // Delete resource
 func (r resourceGrant) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var state Grant
@@ -571,11 +1114,12 @@ func (r *resourceGrant) ImportState(ctx context.Context, req resource.ImportStat
 }
 
 type Grant struct {
-	ID         types.String `tfsdk:"id"`
-	Database   types.String `tfsdk:"database"`
-	Role       types.String `tfsdk:"role"`
-	Schema     types.String `tfsdk:"schema"`
-	ObjectType types.String `tfsdk:"object_type"`
-	Objects    types.List   `tfsdk:"objects"`
-	Privileges types.List   `tfsdk:"privileges"`
+	ID              types.String `tfsdk:"id"`
+	Database        types.String `tfsdk:"database"`
+	Role            types.String `tfsdk:"role"`
+	Schema          types.String `tfsdk:"schema"`
+	ObjectType      types.String `tfsdk:"object_type"`
+	Objects         types.Set    `tfsdk:"objects"`
+	Privileges      types.Set    `tfsdk:"privileges"`
+	WithGrantOption types.Bool   `tfsdk:"with_grant_option"`
 }
@@ -0,0 +1,412 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jackc/pgx/v5"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &resourceGrantPrivilege{}
+	_ resource.ResourceWithConfigure   = &resourceGrantPrivilege{}
+	_ resource.ResourceWithImportState = &resourceGrantPrivilege{}
+)
+
+func NewGrantPrivilegeResource() resource.Resource {
+	return &resourceGrantPrivilege{}
+}
+
+type resourceGrantPrivilege struct {
+	p *cockroachdbProvider
+}
+
+func (r *resourceGrantPrivilege) Metadata(_ context.Context, _ resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = "cockroachdb_grant_privilege"
+}
+
+func (r *resourceGrantPrivilege) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Ensures a role has privileges on a target without taking away any others. Unlike `cockroachdb_grant`, only the listed privileges are revoked on destroy.",
+		Attributes: map[string]schema.Attribute{
+			"role": schema.StringAttribute{
+				Description: "Target role name",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"database": schema.StringAttribute{
+				Description: "Target database name",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"schema": schema.StringAttribute{
+				Description: "Target schema name. Objects are in `public` when it is not set.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"object_type": schema.StringAttribute{
+				Description: "Object type. Must be one of the following:  database, schema, table, sequence, type, function, procedure, or external_connection",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.RegexMatches(regexp.MustCompile(`^(database|schema|table|sequence|type|function|procedure|external_connection)$`), "Value must match RegExp: ^(database|schema|table|sequence|type|function|procedure|external_connection)$"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"objects": schema.SetAttribute{
+				Description: "Objects to grant privileges on, e.g. `tractor`, `public.tractor` or, for functions and procedures, a signature such as `area(INT, INT)`. Each part of a name is quoted, so it must be spelled exactly as the object is named. Leave empty to grant on all tables, sequences, functions or procedures in `schema`. Required for types and external connections.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"privileges": schema.SetAttribute{
+				Description: "Privileges to grant. Names are compared case-insensitively. `ALL` is not allowed, since revoking it on destroy would take away privileges granted by others.",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.NoneOfCaseInsensitive("ALL")),
+				},
+			},
+			"with_grant_option": schema.BoolAttribute{
+				Description: "Allows the role to grant the privileges to other roles. Default value is false.",
+				Optional:    true,
+			},
+			"id": schema.StringAttribute{
+				Description: "ID of the grant privilege, `role|database|schema|object_type|objects|privileges` with comma separated objects and privileges",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *resourceGrantPrivilege) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.p = req.ProviderData.(*cockroachdbProvider)
+}
+
+func getGrantPrivilegeID(ctx context.Context, grant Grant) types.String {
+	objects := []string{}
+	grant.Objects.ElementsAs(ctx, &objects, false)
+	sort.Strings(objects)
+	privileges := []string{}
+	grant.Privileges.ElementsAs(ctx, &privileges, false)
+	sort.Strings(privileges)
+
+	return types.StringValue(strings.Join([]string{
+		grant.Role.ValueString(),
+		grant.Database.ValueString(),
+		grant.Schema.ValueString(),
+		grant.ObjectType.ValueString(),
+		strings.Join(objects, ","),
+		strings.Join(privileges, ","),
+	}, "|"))
+}
+
+// readGrantPrivilege reads the role's privileges and keeps only those the
+// grant manages, so privileges granted by others never show up as drift. The
+// schema is never taken from the privileges, the import ID carries it.
+func readGrantPrivilege(ctx context.Context, conn dbExecutor, grant *Grant) error {
+	held, err := readObjectPrivileges(ctx, conn, grant)
+	if err != nil {
+		return err
+	}
+
+	keep, err := keepGrantWithoutObjects(ctx, conn, *grant, held)
+	if err != nil || keep {
+		return err
+	}
+
+	reconcileGrant(ctx, grant, held, false)
+
+	return nil
+}
+
+// revokeGrantPrivilege revokes only the privileges the grant lists
+func revokeGrantPrivilege(ctx context.Context, conn dbExecutor, grant Grant) error {
+	// Nothing is left to revoke once the privileges were revoked elsewhere
+	if len(grant.Privileges.Elements()) == 0 {
+		return nil
+	}
+
+	return execQueries(ctx, conn, []string{getRevokePrivilegesQuery(ctx, grant)})
+}
+
+// Create a new resource
+func (r *resourceGrantPrivilege) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan Grant
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Validate params
+	if err := validateGrant(ctx, plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Plan validation error",
+			err.Error(),
+		)
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Connecting to database '%s'", plan.Database.ValueString()))
+
+	// Connect to db
+	conn, err := r.p.Conn(ctx, plan.Database.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach connection error",
+			err.Error(),
+		)
+		return
+	}
+	defer conn.Close(ctx)
+
+	// Granting what the role already holds is a no-op, so nothing is revoked first
+	err = grantRolePrivileges(ctx, conn, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach sql error",
+			err.Error(),
+		)
+		return
+	}
+	plan.ID = getGrantPrivilegeID(ctx, plan)
+
+	// Read back what was just set in DB
+	err = readGrantPrivilege(ctx, conn, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach sql error",
+			err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information
+func (r *resourceGrantPrivilege) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state Grant
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// In cases where we are importing state from a single ID, parse the ID into the proper pieces
+	if state.Role.IsNull() {
+		idPieces := strings.Split(state.ID.ValueString(), "|")
+		if len(idPieces) != 6 {
+			resp.Diagnostics.AddError(
+				"Invalid grant privilege ID",
+				"Expected `role|database|schema|object_type|objects|privileges`, got "+state.ID.ValueString(),
+			)
+			return
+		}
+
+		state.Role = types.StringValue(idPieces[0])
+		state.Database = types.StringValue(idPieces[1])
+		if idPieces[2] != "" {
+			state.Schema = types.StringValue(idPieces[2])
+		}
+		state.ObjectType = types.StringValue(idPieces[3])
+		if idPieces[4] != "" {
+			state.Objects, _ = types.SetValueFrom(ctx, types.StringType, strings.Split(idPieces[4], ","))
+		}
+		state.Privileges, _ = types.SetValueFrom(ctx, types.StringType, strings.Split(idPieces[5], ","))
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Connecting to database '%s'", state.Database.ValueString()))
+
+	// Connect to db
+	conn, err := r.p.Conn(ctx, state.Database.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach connection error",
+			err.Error(),
+		)
+		return
+	}
+	defer conn.Close(ctx)
+
+	err = readGrantPrivilege(ctx, conn, &state)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach sql error",
+			err.Error(),
+		)
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update resource
+func (r *resourceGrantPrivilege) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var (
+		state Grant
+		plan  Grant
+	)
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Validate params
+	if err := validateGrant(ctx, plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Plan validation error",
+			err.Error(),
+		)
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Connecting to database '%s'", plan.Database.ValueString()))
+
+	// Connect to db
+	conn, err := r.p.Conn(ctx, plan.Database.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach connection error",
+			err.Error(),
+		)
+		return
+	}
+	defer conn.Close(ctx)
+
+	// A renamed role keeps its privileges, so revoke them under its new name
+	state.Role, err = repointRenamedRole(ctx, conn, state.Role, plan.Role)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach sql error",
+			err.Error(),
+		)
+		return
+	}
+
+	err = executeInTx(ctx, conn, func(tx pgx.Tx) error {
+		if sameGrantTarget(ctx, state, plan) {
+			return execQueries(ctx, tx, getGrantDiffQueries(ctx, state, plan, false))
+		}
+
+		// Only take away what this resource granted before granting on the new target
+		if err := revokeGrantPrivilege(ctx, tx, state); err != nil {
+			return err
+		}
+
+		return grantRolePrivileges(ctx, tx, &plan)
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach sql error",
+			err.Error(),
+		)
+		return
+	}
+	plan.ID = getGrantPrivilegeID(ctx, plan)
+
+	// Read back what was set in DB
+	err = readGrantPrivilege(ctx, conn, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach sql error",
+			err.Error(),
+		)
+		return
+	}
+
+	// Update state with what was actually stored
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete resource
+func (r *resourceGrantPrivilege) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state Grant
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Connecting to database '%s'", state.Database.ValueString()))
+
+	// Connect to db
+	conn, err := r.p.Conn(ctx, state.Database.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach connection error",
+			err.Error(),
+		)
+		return
+	}
+	defer conn.Close(ctx)
+
+	// Revoke only the listed privileges, leaving any others in place
+	err = revokeGrantPrivilege(ctx, conn, state)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach sql error",
+			err.Error(),
+		)
+		return
+	}
+}
+
+func (r *resourceGrantPrivilege) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGrantPrivilegeResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: providerFactories,
+		PreCheck:                 func() { createTractorTable(t) },
+		CheckDestroy:             destroyTractorTable,
+		Steps: []resource.TestStep{
+			// Create and Read testing, both privileges coexist
+			{
+				Config: prefixProvider(`
+resource "cockroachdb_role" "test_role" {
+	name = "test_role"
+}
+
+resource "cockroachdb_grant_privilege" "test_select" {
+	role        = cockroachdb_role.test_role.name
+	database    = "defaultdb"
+	schema      = "public"
+	object_type = "table"
+	objects     = ["tractor"]
+	privileges  = ["SELECT"]
+}
+
+resource "cockroachdb_grant_privilege" "test_insert" {
+	role        = cockroachdb_role.test_role.name
+	database    = "defaultdb"
+	schema      = "public"
+	object_type = "table"
+	objects     = ["tractor"]
+	privileges  = ["INSERT"]
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cockroachdb_grant_privilege.test_select", "id", "test_role|defaultdb|public|table|tractor|SELECT"),
+					resource.TestCheckResourceAttr("cockroachdb_grant_privilege.test_select", "privileges.#", "1"),
+					resource.TestCheckResourceAttr("cockroachdb_grant_privilege.test_insert", "privileges.#", "1"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "cockroachdb_grant_privilege.test_select",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Destroying one leaves the other's privilege in place
+			{
+				Config: prefixProvider(`
+resource "cockroachdb_role" "test_role" {
+	name = "test_role"
+}
+
+resource "cockroachdb_grant_privilege" "test_insert" {
+	role        = cockroachdb_role.test_role.name
+	database    = "defaultdb"
+	schema      = "public"
+	object_type = "table"
+	objects     = ["tractor"]
+	privileges  = ["INSERT"]
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("cockroachdb_grant_privilege.test_insert", "privileges.*", "INSERT"),
+				),
+			},
+			// Objects without a schema are in public and don't plan a replacement
+			{
+				Config: prefixProvider(`
+resource "cockroachdb_role" "test_role" {
+	name = "test_role"
+}
+
+resource "cockroachdb_grant_privilege" "test_insert" {
+	role        = cockroachdb_role.test_role.name
+	database    = "defaultdb"
+	object_type = "table"
+	objects     = ["tractor"]
+	privileges  = ["INSERT"]
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("cockroachdb_grant_privilege.test_insert", "schema"),
+				),
+			},
+			{
+				Config: prefixProvider(`
+resource "cockroachdb_role" "test_role" {
+	name = "test_role"
+}
+
+resource "cockroachdb_grant_privilege" "test_insert" {
+	role        = cockroachdb_role.test_role.name
+	database    = "defaultdb"
+	object_type = "table"
+	objects     = ["tractor"]
+	privileges  = ["INSERT"]
+}
+`),
+				PlanOnly: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -65,7 +65,7 @@ func (r *resourceGrantRole) Configure(_ context.Context, req resource.ConfigureR
 	r.p = req.ProviderData.(*cockroachdbProvider)
 }
 
-func CreateGrantRole(ctx context.Context, conn *pgx.Conn, grantRole *GrantRole) error {
+func CreateGrantRole(ctx context.Context, conn dbExecutor, grantRole *GrantRole) error {
 	var err error
 
 	// Execute SQL
@@ -198,8 +198,19 @@ func (r *resourceGrantRole) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
-	// Delete Grant
-	err = DeleteGrantRole(ctx, conn, state)
+	// Add any plans
+	var plan GrantRole
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Memberships move with renamed roles, so point state at the new names
+	state.Role, err = repointRenamedRole(ctx, conn, state.Role, plan.Role)
+	if err == nil {
+		state.User, err = repointRenamedRole(ctx, conn, state.User, plan.User)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Cockroach sql error",
@@ -208,16 +219,19 @@ func (r *resourceGrantRole) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
-	// Add any plans
-	var plan GrantRole
-	diags = req.Plan.Get(ctx, &plan)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
+	if state.Role.ValueString() == plan.Role.ValueString() && state.User.ValueString() == plan.User.ValueString() {
+		// Only the names changed, the membership already exists
+		plan.ID = types.StringValue(plan.Role.ValueString() + "|" + plan.User.ValueString())
+	} else {
+		// Swap the Grant Role in one transaction
+		err = executeInTx(ctx, conn, func(tx pgx.Tx) error {
+			if err := DeleteGrantRole(ctx, tx, state); err != nil {
+				return err
+			}
+
+			return CreateGrantRole(ctx, tx, &plan)
+		})
 	}
-
-	// Create the Grant Role
-	err = CreateGrantRole(ctx, conn, &plan)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Cockroach sql error",
@@ -234,7 +248,7 @@ func (r *resourceGrantRole) Update(ctx context.Context, req resource.UpdateReque
 	}
 }
 
-func DeleteGrantRole(ctx context.Context, conn *pgx.Conn, grantRole GrantRole) error {
+func DeleteGrantRole(ctx context.Context, conn dbExecutor, grantRole GrantRole) error {
 	var err error
 
 	// Execute SQL
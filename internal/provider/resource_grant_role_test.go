@@ -40,6 +40,30 @@ resource "cockroachdb_grant_role" "test_grant_role" {
 				ImportStateVerify:       true,
 				ImportStateVerifyIgnore: []string{"id"},
 			},
+			// Rename testing
+			{
+				Config: prefixProvider(`
+resource "cockroachdb_role" "test_role" {
+	name = "test_role_renamed"
+}
+
+resource "cockroachdb_role" "test_user" {
+	name = "test_user"
+	login = true
+	password = "test_role"
+}
+
+resource "cockroachdb_grant_role" "test_grant_role" {
+	user = cockroachdb_role.test_user.name
+	role = cockroachdb_role.test_role.name
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cockroachdb_role.test_role", "id", "test_role_renamed"),
+					resource.TestCheckResourceAttr("cockroachdb_grant_role.test_grant_role", "role", "test_role_renamed"),
+					resource.TestCheckResourceAttr("cockroachdb_grant_role.test_grant_role", "id", "test_role_renamed|test_user"),
+				),
+			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
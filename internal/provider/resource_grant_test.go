@@ -2,6 +2,9 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"reflect"
+	"sort"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -35,7 +38,7 @@ resource "cockroachdb_grant" "test_schema_grant" {
 					resource.TestCheckResourceAttr("cockroachdb_grant.test_schema_grant", "role", "test_role"),
 					resource.TestCheckResourceAttr("cockroachdb_grant.test_schema_grant", "database", "defaultdb"),
 					resource.TestCheckResourceAttr("cockroachdb_grant.test_schema_grant", "object_type", "database"),
-					resource.TestCheckResourceAttr("cockroachdb_grant.test_schema_grant", "privileges.0", "ALL"),
+					resource.TestCheckTypeSetElemAttr("cockroachdb_grant.test_schema_grant", "privileges.*", "ALL"),
 				),
 			},
 			// ImportState testing
@@ -65,7 +68,7 @@ resource "cockroachdb_grant" "test_schema_grant" {
 					resource.TestCheckResourceAttr("cockroachdb_grant.test_schema_grant", "database", "defaultdb"),
 					resource.TestCheckResourceAttr("cockroachdb_grant.test_schema_grant", "schema", "public"),
 					resource.TestCheckResourceAttr("cockroachdb_grant.test_schema_grant", "object_type", "schema"),
-					resource.TestCheckResourceAttr("cockroachdb_grant.test_schema_grant", "privileges.0", "USAGE"),
+					resource.TestCheckTypeSetElemAttr("cockroachdb_grant.test_schema_grant", "privileges.*", "USAGE"),
 				),
 			},
 			// ImportState testing
@@ -97,8 +100,8 @@ resource "cockroachdb_grant" "test_schema_grant" {
 					resource.TestCheckResourceAttr("cockroachdb_grant.test_schema_grant", "database", "defaultdb"),
 					resource.TestCheckResourceAttr("cockroachdb_grant.test_schema_grant", "schema", "public"),
 					resource.TestCheckResourceAttr("cockroachdb_grant.test_schema_grant", "object_type", "table"),
-					resource.TestCheckResourceAttr("cockroachdb_grant.test_schema_grant", "objects.0", "tractor"),
-					resource.TestCheckResourceAttr("cockroachdb_grant.test_schema_grant", "privileges.0", "ALL"),
+					resource.TestCheckTypeSetElemAttr("cockroachdb_grant.test_schema_grant", "objects.*", "tractor"),
+					resource.TestCheckTypeSetElemAttr("cockroachdb_grant.test_schema_grant", "privileges.*", "ALL"),
 				),
 			},
 			// ImportState testing
@@ -108,6 +111,175 @@ resource "cockroachdb_grant" "test_schema_grant" {
 				ImportStateVerify:       true,
 				ImportStateVerifyIgnore: []string{"id"},
 			},
+			// Case-insensitive privileges and objects testing
+			{
+				Config: prefixProvider(`
+resource "cockroachdb_role" "test_role" {
+	name = "test_role"
+}
+
+resource "cockroachdb_grant" "test_schema_grant" {
+	role        = cockroachdb_role.test_role.name
+	database    = "defaultdb"
+	schema      = "public"
+	object_type = "table"
+	objects     = ["Tractor"]
+	privileges  = ["select", "INSERT"]
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("cockroachdb_grant.test_schema_grant", "objects.*", "Tractor"),
+					resource.TestCheckTypeSetElemAttr("cockroachdb_grant.test_schema_grant", "privileges.*", "select"),
+					resource.TestCheckTypeSetElemAttr("cockroachdb_grant.test_schema_grant", "privileges.*", "INSERT"),
+				),
+			},
+			// Reordering privileges doesn't plan a change
+			{
+				Config: prefixProvider(`
+resource "cockroachdb_role" "test_role" {
+	name = "test_role"
+}
+
+resource "cockroachdb_grant" "test_schema_grant" {
+	role        = cockroachdb_role.test_role.name
+	database    = "defaultdb"
+	schema      = "public"
+	object_type = "table"
+	objects     = ["Tractor"]
+	privileges  = ["INSERT", "select"]
+}
+`),
+				PlanOnly: true,
+			},
+			// Drift testing, a privilege granted outside of Terraform is revoked
+			{
+				PreConfig: func() { grantOnTractor(t, "DELETE") },
+				Config: prefixProvider(`
+resource "cockroachdb_role" "test_role" {
+	name = "test_role"
+}
+
+resource "cockroachdb_grant" "test_schema_grant" {
+	role        = cockroachdb_role.test_role.name
+	database    = "defaultdb"
+	schema      = "public"
+	object_type = "table"
+	objects     = ["Tractor"]
+	privileges  = ["INSERT", "select"]
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cockroachdb_grant.test_schema_grant", "objects.#", "1"),
+					resource.TestCheckResourceAttr("cockroachdb_grant.test_schema_grant", "privileges.#", "2"),
+				),
+			},
+			// Grant option testing
+			{
+				Config: prefixProvider(`
+resource "cockroachdb_role" "test_role" {
+	name = "test_role"
+}
+
+resource "cockroachdb_grant" "test_schema_grant" {
+	role              = cockroachdb_role.test_role.name
+	database          = "defaultdb"
+	schema            = "public"
+	object_type       = "table"
+	objects           = ["Tractor"]
+	privileges        = ["INSERT", "select"]
+	with_grant_option = true
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cockroachdb_grant.test_schema_grant", "with_grant_option", "true"),
+				),
+			},
+			{
+				Config: prefixProvider(`
+resource "cockroachdb_role" "test_role" {
+	name = "test_role"
+}
+
+resource "cockroachdb_grant" "test_schema_grant" {
+	role              = cockroachdb_role.test_role.name
+	database          = "defaultdb"
+	schema            = "public"
+	object_type       = "table"
+	objects           = ["Tractor"]
+	privileges        = ["INSERT", "select"]
+	with_grant_option = false
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cockroachdb_grant.test_schema_grant", "with_grant_option", "false"),
+					resource.TestCheckTypeSetElemAttr("cockroachdb_grant.test_schema_grant", "privileges.*", "INSERT"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccGrantResourceObjectTypes(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: providerFactories,
+		PreCheck:                 func() { createImplementObjects(t) },
+		CheckDestroy:             destroyImplementObjects,
+		Steps: []resource.TestStep{
+			// Sequence and type testing
+			{
+				Config: prefixProvider(`
+resource "cockroachdb_role" "test_role" {
+	name = "test_role"
+}
+
+resource "cockroachdb_grant" "test_sequence_grant" {
+	role        = cockroachdb_role.test_role.name
+	database    = "defaultdb"
+	schema      = "public"
+	object_type = "sequence"
+	objects     = ["implement_seq"]
+	privileges  = ["USAGE", "SELECT"]
+}
+
+resource "cockroachdb_grant" "test_type_grant" {
+	role        = cockroachdb_role.test_role.name
+	database    = "defaultdb"
+	schema      = "public"
+	object_type = "type"
+	objects     = ["implement_kind"]
+	privileges  = ["USAGE"]
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("cockroachdb_grant.test_sequence_grant", "objects.*", "implement_seq"),
+					resource.TestCheckTypeSetElemAttr("cockroachdb_grant.test_sequence_grant", "privileges.*", "USAGE"),
+					resource.TestCheckTypeSetElemAttr("cockroachdb_grant.test_sequence_grant", "privileges.*", "SELECT"),
+					resource.TestCheckTypeSetElemAttr("cockroachdb_grant.test_type_grant", "objects.*", "implement_kind"),
+					resource.TestCheckTypeSetElemAttr("cockroachdb_grant.test_type_grant", "privileges.*", "USAGE"),
+				),
+			},
+			// Privilege removal testing, only SELECT is revoked
+			{
+				Config: prefixProvider(`
+resource "cockroachdb_role" "test_role" {
+	name = "test_role"
+}
+
+resource "cockroachdb_grant" "test_sequence_grant" {
+	role        = cockroachdb_role.test_role.name
+	database    = "defaultdb"
+	schema      = "public"
+	object_type = "sequence"
+	objects     = ["implement_seq"]
+	privileges  = ["USAGE"]
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cockroachdb_grant.test_sequence_grant", "privileges.#", "1"),
+					resource.TestCheckTypeSetElemAttr("cockroachdb_grant.test_sequence_grant", "privileges.*", "USAGE"),
+				),
+			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
@@ -180,3 +352,424 @@ func destroyTractorTable(s *terraform.State) error {
 
 	return nil
 }
+
+func createImplementObjects(t *testing.T) {
+	conn, err := getDbConn()
+	if err != nil {
+		t.Error(
+			"Cockroach database connection error",
+			err.Error(),
+		)
+	}
+
+	_, err = conn.Exec(context.Background(), `
+		CREATE SEQUENCE implement_seq;
+		CREATE TYPE implement_kind AS ENUM ('plow', 'seeder');
+	`)
+	if err != nil {
+		t.Error(
+			"Cockroach error creating test objects",
+			err.Error(),
+		)
+	}
+}
+
+func destroyImplementObjects(s *terraform.State) error {
+	conn, err := getDbConn()
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.Exec(context.Background(), `DROP SEQUENCE implement_seq; DROP TYPE implement_kind;`)
+	return err
+}
+
+func grantOnTractor(t *testing.T, privilege string) {
+	conn, err := getDbConn()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close(context.Background())
+
+	_, err = conn.Exec(context.Background(), fmt.Sprintf("GRANT %s ON TABLE tractor TO test_role", privilege))
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNormalizePrivileges(t *testing.T) {
+	tests := []struct {
+		name       string
+		objectType string
+		reported   []string
+		current    []string
+		expected   []string
+	}{
+		{
+			name:       "table privileges granted one by one are ALL",
+			objectType: "table",
+			reported:   []string{"BACKUP", "CHANGEFEED", "CREATE", "DELETE", "DROP", "INSERT", "SELECT", "UPDATE", "ZONECONFIG"},
+			current:    []string{"ALL"},
+			expected:   []string{"ALL"},
+		},
+		{
+			name:       "table ALL covers the privileges listed",
+			objectType: "table",
+			reported:   []string{"ALL"},
+			current:    []string{"backup", "changefeed", "create", "delete", "drop", "insert", "select", "update", "zoneconfig"},
+			expected:   []string{"backup", "changefeed", "create", "delete", "drop", "insert", "select", "update", "zoneconfig"},
+		},
+		{
+			name:       "some table privileges are not ALL",
+			objectType: "table",
+			reported:   []string{"INSERT", "SELECT"},
+			current:    []string{"ALL"},
+			expected:   []string{"INSERT", "SELECT"},
+		},
+		{
+			name:       "database privileges granted one by one are ALL",
+			objectType: "database",
+			reported:   []string{"BACKUP", "CONNECT", "CREATE", "DROP", "RESTORE", "ZONECONFIG"},
+			current:    []string{"ALL"},
+			expected:   []string{"ALL"},
+		},
+		{
+			name:       "sequence privileges granted one by one are ALL",
+			objectType: "sequence",
+			reported:   []string{"CREATE", "DROP", "INSERT", "SELECT", "UPDATE", "USAGE", "ZONECONFIG"},
+			current:    []string{"ALL"},
+			expected:   []string{"ALL"},
+		},
+		{
+			name:       "schema privileges keep their spelling",
+			objectType: "schema",
+			reported:   []string{"CREATE", "USAGE"},
+			current:    []string{"usage", "create"},
+			expected:   []string{"create", "usage"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			normalized := normalizePrivileges(test.objectType, test.reported, test.current)
+
+			// Compared case-sensitively, privileges must keep their configured spelling
+			sort.Strings(normalized)
+			if !reflect.DeepEqual(normalized, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, normalized)
+			}
+		})
+	}
+}
+
+// testGrant builds a grant on the objects of tractor's schema. Nil privileges
+// are null, as on import.
+func testGrant(objectType string, objects []string, privileges []string, withGrantOption types.Bool) Grant {
+	grant := Grant{
+		Database:        types.StringValue("defaultdb"),
+		Role:            types.StringValue("test_role"),
+		Schema:          types.StringNull(),
+		ObjectType:      types.StringValue(objectType),
+		Objects:         types.SetNull(types.StringType),
+		Privileges:      types.SetNull(types.StringType),
+		WithGrantOption: withGrantOption,
+	}
+	if objects != nil {
+		grant.Objects, _ = types.SetValueFrom(context.Background(), types.StringType, objects)
+	}
+	if privileges != nil {
+		grant.Privileges, _ = types.SetValueFrom(context.Background(), types.StringType, privileges)
+	}
+
+	return grant
+}
+
+// sortedElements returns the values of a set of strings in order, or nil
+// when the set is null
+func sortedElements(set types.Set) []string {
+	if set.IsNull() {
+		return nil
+	}
+
+	values := []string{}
+	set.ElementsAs(context.Background(), &values, false)
+	sort.Strings(values)
+
+	return values
+}
+
+func TestReconcileGrant(t *testing.T) {
+	tests := []struct {
+		name                    string
+		grant                   Grant
+		held                    []*objectPrivileges
+		authoritative           bool
+		expectedObjects         []string
+		expectedPrivileges      []string
+		expectedWithGrantOption types.Bool
+	}{
+		{
+			name:  "drift on one configured object",
+			grant: testGrant("table", []string{"implement", "tractor"}, []string{"SELECT"}, types.BoolNull()),
+			held: []*objectPrivileges{
+				{name: "implement", privileges: []string{"SELECT"}},
+				{name: "tractor", privileges: []string{"INSERT", "SELECT"}},
+			},
+			authoritative:           true,
+			expectedObjects:         []string{"implement"},
+			expectedPrivileges:      []string{"SELECT"},
+			expectedWithGrantOption: types.BoolNull(),
+		},
+		{
+			name:                    "configured object missing from the server",
+			grant:                   testGrant("table", []string{"implement", "tractor"}, []string{"SELECT"}, types.BoolNull()),
+			held:                    []*objectPrivileges{{name: "tractor", privileges: []string{"SELECT"}}},
+			authoritative:           true,
+			expectedObjects:         []string{"tractor"},
+			expectedPrivileges:      []string{"SELECT"},
+			expectedWithGrantOption: types.BoolNull(),
+		},
+		{
+			name:                    "no configured object holds the privileges",
+			grant:                   testGrant("table", []string{"tractor"}, []string{"SELECT"}, types.BoolValue(false)),
+			held:                    []*objectPrivileges{},
+			authoritative:           true,
+			expectedObjects:         []string{"tractor"},
+			expectedPrivileges:      []string{},
+			expectedWithGrantOption: types.BoolValue(false),
+		},
+		{
+			name:  "import",
+			grant: testGrant("table", nil, nil, types.BoolNull()),
+			held: []*objectPrivileges{
+				{name: "implement", privileges: []string{"SELECT"}, grantable: true},
+				{name: "tractor", privileges: []string{"SELECT"}, grantable: true},
+			},
+			authoritative:           true,
+			expectedObjects:         []string{"implement", "tractor"},
+			expectedPrivileges:      []string{"SELECT"},
+			expectedWithGrantOption: types.BoolValue(true),
+		},
+		{
+			name:                    "database grant with an extra privilege",
+			grant:                   testGrant("database", nil, []string{"CONNECT"}, types.BoolNull()),
+			held:                    []*objectPrivileges{{privileges: []string{"CONNECT", "CREATE"}}},
+			authoritative:           true,
+			expectedObjects:         nil,
+			expectedPrivileges:      []string{"CONNECT", "CREATE"},
+			expectedWithGrantOption: types.BoolNull(),
+		},
+		{
+			name:                    "schema grant with grant option",
+			grant:                   testGrant("schema", nil, []string{"usage"}, types.BoolValue(true)),
+			held:                    []*objectPrivileges{{privileges: []string{"USAGE"}, grantable: true}},
+			authoritative:           true,
+			expectedObjects:         nil,
+			expectedPrivileges:      []string{"usage"},
+			expectedWithGrantOption: types.BoolValue(true),
+		},
+		{
+			name:                    "every privilege in the schema revoked",
+			grant:                   testGrant("table", nil, []string{"SELECT"}, types.BoolNull()),
+			held:                    []*objectPrivileges{},
+			authoritative:           true,
+			expectedObjects:         nil,
+			expectedPrivileges:      []string{},
+			expectedWithGrantOption: types.BoolNull(),
+		},
+		{
+			name:                    "privileges granted by others are ignored when not authoritative",
+			grant:                   testGrant("table", []string{"tractor"}, []string{"SELECT"}, types.BoolNull()),
+			held:                    []*objectPrivileges{{name: "tractor", privileges: []string{"INSERT", "SELECT"}, grantable: true}},
+			authoritative:           false,
+			expectedObjects:         []string{"tractor"},
+			expectedPrivileges:      []string{"SELECT"},
+			expectedWithGrantOption: types.BoolNull(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			grant := test.grant
+			reconcileGrant(context.Background(), &grant, test.held, test.authoritative)
+
+			if objects := sortedElements(grant.Objects); !reflect.DeepEqual(objects, test.expectedObjects) {
+				t.Errorf("expected objects %v, got %v", test.expectedObjects, objects)
+			}
+			if privileges := sortedElements(grant.Privileges); !reflect.DeepEqual(privileges, test.expectedPrivileges) {
+				t.Errorf("expected privileges %v, got %v", test.expectedPrivileges, privileges)
+			}
+			if !grant.WithGrantOption.Equal(test.expectedWithGrantOption) {
+				t.Errorf("expected with_grant_option %s, got %s", test.expectedWithGrantOption, grant.WithGrantOption)
+			}
+		})
+	}
+}
+
+func TestGetGrantTarget(t *testing.T) {
+	tests := []struct {
+		name     string
+		grant    Grant
+		schema   string
+		expected string
+	}{
+		{
+			name:     "mixed case table",
+			grant:    testGrant("table", []string{"Tractor"}, nil, types.BoolNull()),
+			expected: `TABLE "Tractor"`,
+		},
+		{
+			name:     "table in the grant's schema",
+			grant:    testGrant("table", []string{"tractor"}, nil, types.BoolNull()),
+			schema:   "Farm",
+			expected: `TABLE "Farm"."tractor"`,
+		},
+		{
+			name:     "qualified sequence",
+			grant:    testGrant("sequence", []string{"farm.tractor_seq"}, nil, types.BoolNull()),
+			expected: `SEQUENCE "farm"."tractor_seq"`,
+		},
+		{
+			name:     "function signature",
+			grant:    testGrant("function", []string{"area(INT, INT)"}, nil, types.BoolNull()),
+			expected: `FUNCTION "area"(INT, INT)`,
+		},
+		{
+			name:     "type",
+			grant:    testGrant("type", []string{"Color"}, nil, types.BoolNull()),
+			expected: `TYPE "Color"`,
+		},
+		{
+			name:     "external connection",
+			grant:    testGrant("external_connection", []string{"backup.bucket"}, nil, types.BoolNull()),
+			expected: `EXTERNAL CONNECTION "backup.bucket"`,
+		},
+		{
+			name:     "every table",
+			grant:    testGrant("table", []string{"*"}, nil, types.BoolNull()),
+			expected: `TABLE *`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			grant := test.grant
+			if test.schema != "" {
+				grant.Schema = types.StringValue(test.schema)
+			}
+
+			if target := getGrantTarget(context.Background(), grant); target != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, target)
+			}
+		})
+	}
+}
+
+func TestGetGrantDiffQueries(t *testing.T) {
+	tractor := []string{"tractor"}
+	both := []string{"tractor", "implement"}
+
+	tests := []struct {
+		name          string
+		state         Grant
+		plan          Grant
+		authoritative bool
+		expected      []string
+	}{
+		{
+			name:          "privilege added",
+			state:         testGrant("table", tractor, []string{"SELECT"}, types.BoolNull()),
+			plan:          testGrant("table", tractor, []string{"SELECT", "INSERT"}, types.BoolNull()),
+			authoritative: true,
+			expected:      []string{`GRANT INSERT ON TABLE "tractor" TO "test_role"`},
+		},
+		{
+			name:          "privilege removed",
+			state:         testGrant("table", tractor, []string{"SELECT", "INSERT"}, types.BoolNull()),
+			plan:          testGrant("table", tractor, []string{"SELECT"}, types.BoolNull()),
+			authoritative: true,
+			expected:      []string{`REVOKE INSERT ON TABLE "tractor" FROM "test_role"`},
+		},
+		{
+			name:          "ALL replaced by a privilege",
+			state:         testGrant("table", tractor, []string{"ALL"}, types.BoolNull()),
+			plan:          testGrant("table", tractor, []string{"SELECT"}, types.BoolNull()),
+			authoritative: true,
+			expected: []string{
+				`REVOKE ALL ON TABLE "tractor" FROM "test_role"`,
+				`GRANT SELECT ON TABLE "tractor" TO "test_role"`,
+			},
+		},
+		{
+			name:          "privilege replaced by ALL",
+			state:         testGrant("table", tractor, []string{"SELECT"}, types.BoolNull()),
+			plan:          testGrant("table", tractor, []string{"ALL"}, types.BoolNull()),
+			authoritative: true,
+			expected: []string{
+				`REVOKE SELECT ON TABLE "tractor" FROM "test_role"`,
+				`GRANT ALL ON TABLE "tractor" TO "test_role"`,
+			},
+		},
+		{
+			name:          "grant option added",
+			state:         testGrant("table", tractor, []string{"SELECT"}, types.BoolNull()),
+			plan:          testGrant("table", tractor, []string{"SELECT"}, types.BoolValue(true)),
+			authoritative: true,
+			expected:      []string{`GRANT SELECT ON TABLE "tractor" TO "test_role" WITH GRANT OPTION`},
+		},
+		{
+			name:          "grant option removed",
+			state:         testGrant("table", tractor, []string{"SELECT"}, types.BoolValue(true)),
+			plan:          testGrant("table", tractor, []string{"SELECT"}, types.BoolValue(false)),
+			authoritative: true,
+			expected:      []string{`REVOKE GRANT OPTION FOR SELECT ON TABLE "tractor" FROM "test_role"`},
+		},
+		{
+			name:          "no-op",
+			state:         testGrant("table", tractor, []string{"SELECT"}, types.BoolNull()),
+			plan:          testGrant("table", tractor, []string{"select"}, types.BoolNull()),
+			authoritative: true,
+			expected:      []string{},
+		},
+		{
+			name:          "object added",
+			state:         testGrant("table", tractor, []string{"SELECT"}, types.BoolNull()),
+			plan:          testGrant("table", both, []string{"SELECT"}, types.BoolNull()),
+			authoritative: true,
+			expected: []string{
+				`REVOKE ALL PRIVILEGES ON TABLE "implement" FROM "test_role"`,
+				`GRANT SELECT ON TABLE "implement" TO "test_role"`,
+			},
+		},
+		{
+			name:          "object removed",
+			state:         testGrant("table", both, []string{"SELECT"}, types.BoolNull()),
+			plan:          testGrant("table", tractor, []string{"SELECT"}, types.BoolNull()),
+			authoritative: true,
+			expected:      []string{`REVOKE ALL PRIVILEGES ON TABLE "implement" FROM "test_role"`},
+		},
+		{
+			name:          "object removed without taking others' privileges",
+			state:         testGrant("table", both, []string{"SELECT"}, types.BoolNull()),
+			plan:          testGrant("table", tractor, []string{"SELECT"}, types.BoolNull()),
+			authoritative: false,
+			expected:      []string{`REVOKE SELECT ON TABLE "implement" FROM "test_role"`},
+		},
+		{
+			name:          "database privilege added",
+			state:         testGrant("database", nil, []string{"CONNECT"}, types.BoolNull()),
+			plan:          testGrant("database", nil, []string{"CONNECT", "CREATE"}, types.BoolNull()),
+			authoritative: true,
+			expected:      []string{`GRANT CREATE ON DATABASE "defaultdb" TO "test_role"`},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			queries := getGrantDiffQueries(context.Background(), test.state, test.plan, test.authoritative)
+			if !reflect.DeepEqual(queries, test.expected) {
+				t.Errorf("expected %q, got %q", test.expected, queries)
+			}
+		})
+	}
+}
@@ -0,0 +1,631 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jackc/pgx/v5"
+	"github.com/lib/pq"
+)
+
+// hbaSetting is the cluster setting holding the host-based authentication
+// configuration
+const hbaSetting = "server.host_based_authentication.configuration"
+
+// hbaLocal is the entry type for connections over a unix socket, which are
+// the only entries without an address
+const hbaLocal = "local"
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &resourceHbaConfig{}
+	_ resource.ResourceWithConfigure      = &resourceHbaConfig{}
+	_ resource.ResourceWithImportState    = &resourceHbaConfig{}
+	_ resource.ResourceWithModifyPlan     = &resourceHbaConfig{}
+	_ resource.ResourceWithValidateConfig = &resourceHbaConfig{}
+)
+
+func NewHbaConfigResource() resource.Resource {
+	return &resourceHbaConfig{}
+}
+
+type resourceHbaConfig struct {
+	p *cockroachdbProvider
+}
+
+func (r *resourceHbaConfig) Metadata(_ context.Context, _ resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = "cockroachdb_hba_config"
+}
+
+func (r *resourceHbaConfig) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the cluster's host-based authentication configuration (`server.host_based_authentication.configuration`). There can only be one per cluster. Destroying it restores the value the setting had before it was created.",
+		Attributes: map[string]schema.Attribute{
+			"rendered": schema.StringAttribute{
+				Description: "Configuration as written to the cluster setting",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"previous_value": schema.StringAttribute{
+				Description: "Value of the cluster setting before this resource was created, restored on destroy",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "ID of the configuration, the name of the cluster setting",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"entry": schema.ListNestedBlock{
+				Description: "Access rule, evaluated in order. The first entry matching a connection's type, database, user and address decides the authentication method.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Description: "Connection type: `local`, `host`, `hostssl` or `hostnossl`",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(hbaLocal, "host", "hostssl", "hostnossl"),
+							},
+						},
+						"database": schema.StringAttribute{
+							Description: "Database name, comma separated names or `all`",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"user": schema.StringAttribute{
+							Description: "User name, comma separated names or `all`",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"address": schema.StringAttribute{
+							Description: "Client address: an IP address range in CIDR notation, a host name or `all`. Required unless `type` is `local`.",
+							Optional:    true,
+						},
+						"method": schema.StringAttribute{
+							Description: "Authentication method, e.g. `cert`, `password`, `cert-password`, `scram-sha-256`, `trust`, `reject`, `gss` or `ldap`",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("cert", "cert-password", "cert-scram-sha-256", "password", "scram-sha-256", "trust", "reject", "gss", "ldap", "jwt_token"),
+							},
+						},
+						"options": schema.MapAttribute{
+							Description: "Method options, e.g. `map_user` or `ldapserver`",
+							Optional:    true,
+							ElementType: types.StringType,
+							Validators: []validator.Map{
+								mapvalidator.SizeAtLeast(1),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *resourceHbaConfig) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.p = req.ProviderData.(*cockroachdbProvider)
+}
+
+// ValidateConfig checks the address of every entry against its type
+func (r *resourceHbaConfig) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config HbaConfig
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.Entries.IsUnknown() {
+		return
+	}
+
+	var entries []HbaEntry
+	resp.Diagnostics.Append(config.Entries.ElementsAs(ctx, &entries, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, entry := range entries {
+		if entry.Type.IsUnknown() || entry.Address.IsUnknown() {
+			continue
+		}
+
+		addressPath := path.Root("entry").AtListIndex(i).AtName("address")
+		if entry.Type.ValueString() == hbaLocal {
+			if !entry.Address.IsNull() {
+				resp.Diagnostics.AddAttributeError(addressPath, "Invalid HBA entry", "`local` entries don't have an address")
+			}
+			continue
+		}
+
+		if entry.Address.IsNull() {
+			resp.Diagnostics.AddAttributeError(addressPath, "Invalid HBA entry", fmt.Sprintf("`%s` entries need an address", entry.Type.ValueString()))
+			continue
+		}
+
+		address := entry.Address.ValueString()
+		if strings.ContainsAny(address, "/:") || net.ParseIP(address) != nil {
+			if _, _, err := net.ParseCIDR(address); err != nil {
+				resp.Diagnostics.AddAttributeError(addressPath, "Invalid HBA entry", fmt.Sprintf("IP addresses must be ranges in CIDR notation, e.g. 10.0.0.0/8: %s", err.Error()))
+			}
+		}
+	}
+}
+
+// ModifyPlan renders the planned entries when they change, so `rendered` is
+// only kept from state while the entries are
+func (r *resourceHbaConfig) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var (
+		plan  HbaConfig
+		state HbaConfig
+	)
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	if resp.Diagnostics.HasError() || (!req.State.Raw.IsNull() && plan.Entries.Equal(state.Entries)) {
+		return
+	}
+
+	plan.Rendered = types.StringUnknown()
+	if !plan.Entries.IsUnknown() {
+		var entries []HbaEntry
+		resp.Diagnostics.Append(plan.Entries.ElementsAs(ctx, &entries, true)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		known := true
+		for _, entry := range entries {
+			known = known && !entry.Type.IsUnknown() && !entry.Database.IsUnknown() && !entry.User.IsUnknown() &&
+				!entry.Address.IsUnknown() && !entry.Method.IsUnknown() && !entry.Options.IsUnknown()
+		}
+		if known {
+			plan.Rendered = types.StringValue(renderHbaConfig(ctx, entries))
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// quoteHbaField double quotes a field when it contains white space, or a `#`
+// that would otherwise start a comment
+func quoteHbaField(field string) string {
+	if strings.ContainsAny(field, " \t\"#") {
+		return `"` + strings.ReplaceAll(field, `"`, `""`) + `"`
+	}
+
+	return field
+}
+
+// renderHbaConfig writes the entries in the pg_hba.conf format the cluster
+// setting expects, one entry per line
+func renderHbaConfig(ctx context.Context, entries []HbaEntry) string {
+	lines := []string{}
+	for _, entry := range entries {
+		fields := []string{entry.Type.ValueString(), quoteHbaField(entry.Database.ValueString()), quoteHbaField(entry.User.ValueString())}
+		if !entry.Address.IsNull() {
+			fields = append(fields, entry.Address.ValueString())
+		}
+		fields = append(fields, entry.Method.ValueString())
+
+		options := map[string]string{}
+		entry.Options.ElementsAs(ctx, &options, false)
+		keys := make([]string, 0, len(options))
+		for key := range options {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fields = append(fields, key+"="+quoteHbaField(options[key]))
+		}
+
+		lines = append(lines, strings.Join(fields, " "))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// splitHbaFields splits a configuration line on white space, keeping double
+// quoted strings together. Anything after a `#` outside of quotes is a
+// comment.
+func splitHbaFields(line string) []string {
+	fields := []string{}
+	var (
+		field   strings.Builder
+		inQuote bool
+		started bool
+	)
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '"' && inQuote && i+1 < len(line) && line[i+1] == '"':
+			field.WriteByte('"')
+			i++
+		case c == '"':
+			inQuote = !inQuote
+			started = true
+		case c == '#' && !inQuote:
+			i = len(line)
+		case (c == ' ' || c == '\t') && !inQuote:
+			if started {
+				fields = append(fields, field.String())
+				field.Reset()
+				started = false
+			}
+		default:
+			field.WriteByte(c)
+			started = true
+		}
+	}
+	if started {
+		fields = append(fields, field.String())
+	}
+
+	return fields
+}
+
+// hbaMaskAddress turns the `address mask` form of a host entry, e.g.
+// `10.0.0.0 255.0.0.0`, into CIDR notation. It returns false when the fields
+// aren't an IP address followed by a mask.
+func hbaMaskAddress(address string, mask string) (string, bool) {
+	ip := net.ParseIP(address)
+	maskIP := net.ParseIP(mask)
+	if ip == nil || maskIP == nil {
+		return "", false
+	}
+
+	if ip.To4() != nil {
+		ip, maskIP = ip.To4(), maskIP.To4()
+		if maskIP == nil {
+			return "", false
+		}
+	}
+
+	ones, bits := net.IPMask(maskIP).Size()
+	if bits == 0 {
+		return "", false
+	}
+
+	return fmt.Sprintf("%s/%d", ip.String(), ones), true
+}
+
+// parseHbaConfig reads the entries back from the cluster setting, skipping
+// comments and blank lines
+func parseHbaConfig(config string) ([]HbaEntry, error) {
+	entries := []HbaEntry{}
+
+	for _, line := range strings.Split(config, "\n") {
+		fields := splitHbaFields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		minFields := 5
+		if fields[0] == hbaLocal {
+			minFields = 4
+		}
+		if len(fields) < minFields {
+			return nil, fmt.Errorf("can't parse HBA entry %q", strings.TrimSpace(line))
+		}
+
+		entry := HbaEntry{
+			Type:     types.StringValue(fields[0]),
+			Database: types.StringValue(fields[1]),
+			User:     types.StringValue(fields[2]),
+			Address:  types.StringNull(),
+			Options:  types.MapNull(types.StringType),
+		}
+		if fields[0] != hbaLocal {
+			entry.Address = types.StringValue(fields[3])
+
+			// The address can also be written as an IP address and a mask
+			if address, ok := hbaMaskAddress(fields[3], fields[4]); ok {
+				if len(fields) < minFields+1 {
+					return nil, fmt.Errorf("can't parse HBA entry %q", strings.TrimSpace(line))
+				}
+				entry.Address = types.StringValue(address)
+				fields = append(fields[:4], fields[5:]...)
+			}
+		}
+		entry.Method = types.StringValue(fields[minFields-1])
+
+		options := map[string]attr.Value{}
+		for _, option := range fields[minFields:] {
+			pieces := strings.SplitN(option, "=", 2)
+			if len(pieces) != 2 {
+				return nil, fmt.Errorf("can't parse HBA option %q", option)
+			}
+			options[pieces[0]] = types.StringValue(pieces[1])
+		}
+		if len(options) > 0 {
+			entry.Options, _ = types.MapValue(types.StringType, options)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func readHbaSetting(ctx context.Context, conn *pgx.Conn) (string, error) {
+	var value string
+	err := conn.QueryRow(ctx, fmt.Sprintf("SHOW CLUSTER SETTING %s", hbaSetting)).Scan(&value)
+
+	return value, err
+}
+
+// writeHbaSetting sets the cluster setting, or resets it to its default when
+// value is empty
+func writeHbaSetting(ctx context.Context, conn *pgx.Conn, value string) error {
+	query := fmt.Sprintf("SET CLUSTER SETTING %s = %s", hbaSetting, pq.QuoteLiteral(value))
+	if value == "" {
+		query = fmt.Sprintf("RESET CLUSTER SETTING %s", hbaSetting)
+	}
+
+	// The configuration itself is left out of the log
+	tflog.Info(ctx, fmt.Sprintf("SET CLUSTER SETTING %s", hbaSetting))
+
+	_, err := conn.Exec(ctx, query)
+	return err
+}
+
+// applyHbaConfig renders the planned entries and writes them to the cluster
+func applyHbaConfig(ctx context.Context, conn *pgx.Conn, plan *HbaConfig) error {
+	var entries []HbaEntry
+	if diags := plan.Entries.ElementsAs(ctx, &entries, false); diags.HasError() {
+		return fmt.Errorf("reading entries: %v", diags)
+	}
+
+	rendered := renderHbaConfig(ctx, entries)
+	if err := writeHbaSetting(ctx, conn, rendered); err != nil {
+		return err
+	}
+
+	plan.ID = types.StringValue(hbaSetting)
+	plan.Rendered = types.StringValue(rendered)
+
+	return nil
+}
+
+// Create a new resource
+func (r *resourceHbaConfig) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan HbaConfig
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Connect to db
+	conn, err := r.p.Conn(ctx, "")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach connection error",
+			err.Error(),
+		)
+		return
+	}
+	defer conn.Close(ctx)
+
+	// Remember the current configuration so it can be restored on destroy
+	previous, err := readHbaSetting(ctx, conn)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach execute sql error",
+			err.Error(),
+		)
+		return
+	}
+	plan.PreviousValue = types.StringValue(previous)
+
+	err = applyHbaConfig(ctx, conn, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach execute sql error",
+			err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information
+func (r *resourceHbaConfig) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state HbaConfig
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Connect to db
+	conn, err := r.p.Conn(ctx, "")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach connection error",
+			err.Error(),
+		)
+		return
+	}
+	defer conn.Close(ctx)
+
+	value, err := readHbaSetting(ctx, conn)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach execute sql error",
+			err.Error(),
+		)
+		return
+	}
+
+	entries, err := parseHbaConfig(value)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid HBA configuration",
+			err.Error(),
+		)
+		return
+	}
+
+	// Imported configurations are restored to the cluster default on destroy
+	if state.PreviousValue.IsNull() {
+		state.PreviousValue = types.StringValue("")
+	}
+
+	state.ID = types.StringValue(hbaSetting)
+	state.Rendered = types.StringValue(value)
+	state.Entries, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: map[string]attr.Type{
+		"type":     types.StringType,
+		"database": types.StringType,
+		"user":     types.StringType,
+		"address":  types.StringType,
+		"method":   types.StringType,
+		"options":  types.MapType{ElemType: types.StringType},
+	}}, entries)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update resource
+func (r *resourceHbaConfig) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan HbaConfig
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Connect to db
+	conn, err := r.p.Conn(ctx, "")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach connection error",
+			err.Error(),
+		)
+		return
+	}
+	defer conn.Close(ctx)
+
+	err = applyHbaConfig(ctx, conn, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach execute sql error",
+			err.Error(),
+		)
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete resource
+func (r *resourceHbaConfig) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state HbaConfig
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Connect to db
+	conn, err := r.p.Conn(ctx, "")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach connection error",
+			err.Error(),
+		)
+		return
+	}
+	defer conn.Close(ctx)
+
+	err = writeHbaSetting(ctx, conn, state.PreviousValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach execute sql error",
+			err.Error(),
+		)
+		return
+	}
+}
+
+func (r *resourceHbaConfig) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+type HbaConfig struct {
+	ID            types.String `tfsdk:"id"`
+	Entries       types.List   `tfsdk:"entry"`
+	Rendered      types.String `tfsdk:"rendered"`
+	PreviousValue types.String `tfsdk:"previous_value"`
+}
+
+type HbaEntry struct {
+	Type     types.String `tfsdk:"type"`
+	Database types.String `tfsdk:"database"`
+	User     types.String `tfsdk:"user"`
+	Address  types.String `tfsdk:"address"`
+	Method   types.String `tfsdk:"method"`
+	Options  types.Map    `tfsdk:"options"`
+}
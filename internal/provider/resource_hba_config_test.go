@@ -0,0 +1,167 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccHbaConfigResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: prefixProvider(`
+resource "cockroachdb_hba_config" "test_hba" {
+  entry {
+    type     = "host"
+    database = "all"
+    user     = "all"
+    address  = "all"
+    method   = "cert-password"
+  }
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cockroachdb_hba_config.test_hba", "rendered", "host all all all cert-password"),
+					resource.TestCheckResourceAttr("cockroachdb_hba_config.test_hba", "entry.#", "1"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "cockroachdb_hba_config.test_hba",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"previous_value"},
+			},
+			// Update testing
+			{
+				Config: prefixProvider(`
+resource "cockroachdb_hba_config" "test_hba" {
+  entry {
+    type     = "host"
+    database = "all"
+    user     = "all"
+    address  = "10.0.0.0/8"
+    method   = "cert-password"
+    options = {
+      map_user = "internal"
+    }
+  }
+
+  entry {
+    type     = "host"
+    database = "all"
+    user     = "all"
+    address  = "all"
+    method   = "cert-password"
+  }
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cockroachdb_hba_config.test_hba", "entry.#", "2"),
+					resource.TestCheckResourceAttr("cockroachdb_hba_config.test_hba", "entry.0.options.map_user", "internal"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestParseHbaConfig(t *testing.T) {
+	entries, err := parseHbaConfig(`# TYPE DATABASE USER ADDRESS METHOD
+host all all 10.0.0.0 255.0.0.0 cert-password # office
+host "app#1" "ops user" 192.168.1.0/24 ldap ldapserver="ldap#1.example.com"
+local all root trust
+host all all ::1 ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff scram-sha-256
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []struct {
+		typ, database, user, address, method string
+		options                              map[string]string
+	}{
+		{"host", "all", "all", "10.0.0.0/8", "cert-password", nil},
+		{"host", "app#1", "ops user", "192.168.1.0/24", "ldap", map[string]string{"ldapserver": "ldap#1.example.com"}},
+		{"local", "all", "root", "", "trust", nil},
+		{"host", "all", "all", "::1/128", "scram-sha-256", nil},
+	}
+	if len(entries) != len(expected) {
+		t.Fatalf("expected %d entries, got %d", len(expected), len(entries))
+	}
+
+	for i, want := range expected {
+		entry := entries[i]
+		if entry.Type.ValueString() != want.typ || entry.Database.ValueString() != want.database ||
+			entry.User.ValueString() != want.user || entry.Address.ValueString() != want.address ||
+			entry.Method.ValueString() != want.method {
+			t.Errorf("entry %d: expected %v, got %s %s %s %s %s", i, want,
+				entry.Type, entry.Database, entry.User, entry.Address, entry.Method)
+		}
+
+		options := map[string]string{}
+		entry.Options.ElementsAs(context.Background(), &options, false)
+		if len(options) != len(want.options) {
+			t.Errorf("entry %d: expected options %v, got %v", i, want.options, options)
+		}
+		for key, value := range want.options {
+			if options[key] != value {
+				t.Errorf("entry %d: expected option %s=%s, got %s", i, key, value, options[key])
+			}
+		}
+	}
+}
+
+func TestParseHbaConfigInvalid(t *testing.T) {
+	for _, config := range []string{
+		"host all all 10.0.0.0 255.0.0.0",
+		"host all all cert",
+		`host all all all cert "map_user`,
+	} {
+		if _, err := parseHbaConfig(config); err == nil {
+			t.Errorf("expected an error parsing %q", config)
+		}
+	}
+}
+
+func TestRenderHbaConfigRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	options, _ := types.MapValueFrom(ctx, types.StringType, map[string]string{
+		"ldapserver":     "ldap.example.com",
+		"ldapbindpasswd": `p#ss "word"`,
+	})
+	entries := []HbaEntry{
+		{
+			Type:     types.StringValue("host"),
+			Database: types.StringValue("app#1"),
+			User:     types.StringValue(`ops "lead"`),
+			Address:  types.StringValue("10.0.0.0/8"),
+			Method:   types.StringValue("ldap"),
+			Options:  options,
+		},
+		{
+			Type:     types.StringValue("local"),
+			Database: types.StringValue("all"),
+			User:     types.StringValue("root"),
+			Address:  types.StringNull(),
+			Method:   types.StringValue("trust"),
+			Options:  types.MapNull(types.StringType),
+		},
+	}
+
+	rendered := renderHbaConfig(ctx, entries)
+	parsed, err := parseHbaConfig(rendered)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(parsed, entries) {
+		t.Errorf("expected %v, got %v from %q", entries, parsed, rendered)
+	}
+}
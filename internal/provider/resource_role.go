@@ -2,22 +2,44 @@ package provider
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
+	"telusag/terraform-provider-cockroachdb/internal/utils"
+	"telusag/terraform-provider-cockroachdb/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/lib/pq"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &resourceRole{}
-	_ resource.ResourceWithConfigure   = &resourceRole{}
-	_ resource.ResourceWithImportState = &resourceRole{}
+	_ resource.Resource                     = &resourceRole{}
+	_ resource.ResourceWithConfigure        = &resourceRole{}
+	_ resource.ResourceWithConfigValidators = &resourceRole{}
+	_ resource.ResourceWithImportState      = &resourceRole{}
+	_ resource.ResourceWithModifyPlan       = &resourceRole{}
 )
 
 func NewRoleResource() resource.Resource {
@@ -37,12 +59,50 @@ func (r *resourceRole) Schema(_ context.Context, _ resource.SchemaRequest, resp
 		Description: "Manages a role.",
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
-				Description: "The name of the role. Must be unique on the CockroachDb server instance where it is configured.",
+				Description: "The name of the role. Must be unique on the CockroachDb server instance where it is configured. Changing it renames the role in place, keeping its grants and memberships.",
 				Required:    true,
 			},
 			"password": schema.StringAttribute{
-				Description: "Sets the role's password. Setting a password creates a user that's able to log in",
+				Description: "Sets the role's password. Setting a password creates a user that's able to log in. Passwords set, changed or removed outside of terraform are detected and reverted on the next apply. Holds the generated password when `generate_password` is set.",
+				Optional:    true,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"password_wo": schema.StringAttribute{
+				Description: "Write-only password for the role, never stored in state. It is only sent to the server when `password_wo_version` changes. Requires Terraform 1.11 or later.",
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("password_wo_version")),
+				},
+			},
+			"password_wo_version": schema.Int64Attribute{
+				Description: "Version of `password_wo`. Change it to set the role's password to the current value of `password_wo`.",
 				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("password_wo")),
+				},
+			},
+			"rotation_triggers": schema.MapAttribute{
+				Description: "Arbitrary map of values that, when changed, generate a new password. Requires `generate_password`.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.AlsoRequires(path.MatchRoot("generate_password")),
+				},
+			},
+			"rotate_after": schema.StringAttribute{
+				Description: "Duration, e.g. `720h`, after which the next apply generates a new password. Requires `generate_password`.",
+				Optional:    true,
+				Validators: []validator.String{
+					validators.Duration(),
+					stringvalidator.AlsoRequires(path.MatchRoot("generate_password")),
+				},
+			},
+			"password_rotated_at": schema.StringAttribute{
+				Description: "Time (RFC 3339) the generated password was last set",
+				Computed:    true,
 			},
 			"create_database": schema.BoolAttribute{
 				Description: "Defines a role's ability to execute CREATE DATABASE. Default value is false.",
@@ -65,12 +125,238 @@ func (r *resourceRole) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				// 	modifiers.BoolDefault(false),
 				// },
 			},
+			"control_job": schema.BoolAttribute{
+				Description: "Defines a role's ability to pause, resume, and cancel jobs. Default value is false.",
+				Optional:    true,
+			},
+			"control_changefeed": schema.BoolAttribute{
+				Description: "Defines a role's ability to run CREATE CHANGEFEED on tables they have SELECT privileges on. Default value is false.",
+				Optional:    true,
+			},
+			"view_activity": schema.BoolAttribute{
+				Description: "Defines a role's ability to view other users' queries and sessions. Default value is false.",
+				Optional:    true,
+			},
+			"view_activity_redacted": schema.BoolAttribute{
+				Description: "Defines a role's ability to view other users' queries and sessions, with constants redacted. Default value is false.",
+				Optional:    true,
+			},
+			"cancel_query": schema.BoolAttribute{
+				Description: "Defines a role's ability to cancel other users' queries and sessions. Default value is false.",
+				Optional:    true,
+			},
+			"modify_cluster_setting": schema.BoolAttribute{
+				Description: "Defines a role's ability to modify cluster settings. Default value is false.",
+				Optional:    true,
+			},
+			"view_cluster_setting": schema.BoolAttribute{
+				Description: "Defines a role's ability to view cluster settings. Default value is false.",
+				Optional:    true,
+			},
+			"no_sql_login": schema.BoolAttribute{
+				Description: "Prevents a role from logging in with the SQL shell or client, while still allowing DB Console login. Default value is false.",
+				Optional:    true,
+			},
+			"create_login": schema.BoolAttribute{
+				Description: "Defines a role's ability to create, alter, and drop other roles' login options and passwords. Default value is false.",
+				Optional:    true,
+			},
+			"replication": schema.BoolAttribute{
+				Description: "Defines a role's ability to use logical replication. Requires CockroachDB v23.1 or later. Default value is false.",
+				Optional:    true,
+			},
+			"valid_until": schema.StringAttribute{
+				Description: "Date and time (RFC 3339) after which the role's password is no longer valid, or `infinity`",
+				Optional:    true,
+				Validators: []validator.String{
+					validators.RFC3339OrInfinity(),
+				},
+			},
+			"member_of": schema.SetAttribute{
+				Description: "Names of the roles this role is a member of. When set it is authoritative: memberships that are not listed are revoked, so don't combine it with `cockroachdb_grant_role` for the same role.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"reassign_owned_to": schema.StringAttribute{
+				Description: "Role that objects owned by this role are reassigned to, in every database, before the role is dropped. Must be applied before the role is destroyed.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"drop_owned": schema.BoolAttribute{
+				Description: "Drop the objects still owned by this role and revoke its privileges, in every database, before the role is dropped. Must be applied before the role is destroyed. Default value is false.",
+				Optional:    true,
+			},
 			"id": schema.StringAttribute{
 				Description: "ID of the role (it's really just the name because they have to be unique)",
 				Computed:    true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"generate_password": schema.SingleNestedBlock{
+				Description: "Generate a random password for the role instead of configuring `password`.",
+				Attributes: map[string]schema.Attribute{
+					"length": schema.Int64Attribute{
+						Description: fmt.Sprintf("Length of the generated password. Default value is %d.", defaultPasswordLength),
+						Optional:    true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(8),
+						},
+					},
+					"lower": schema.BoolAttribute{
+						Description: "Include lowercase letters. Default value is true.",
+						Optional:    true,
+					},
+					"upper": schema.BoolAttribute{
+						Description: "Include uppercase letters. Default value is true.",
+						Optional:    true,
+					},
+					"numeric": schema.BoolAttribute{
+						Description: "Include digits. Default value is true.",
+						Optional:    true,
+					},
+					"special": schema.BoolAttribute{
+						Description: "Include special characters. Default value is false.",
+						Optional:    true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *resourceRole) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(
+			path.MatchRoot("password"),
+			path.MatchRoot("password_wo"),
+			path.MatchRoot("generate_password"),
+		),
+	}
+}
+
+// ModifyPlan decides whether the generated password is kept or rotated. The
+// password is computed so it is otherwise planned as unknown on every update.
+func (r *resourceRole) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Warn about the objects and privileges that deleting the role affects
+	if req.Plan.Raw.IsNull() {
+		var state Role
+
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		r.warnRoleDependencies(ctx, state, &resp.Diagnostics)
+		return
+	}
+
+	var (
+		config Role
+		plan   Role
+		state  *Role
+	)
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if !req.State.Raw.IsNull() {
+		state = &Role{}
+		resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.GeneratePassword.IsNull() {
+		// The password is whatever is configured
+		plan.Password = config.Password
+		plan.PasswordRotatedAt = types.StringNull()
+	} else if state == nil || passwordNeedsRotation(plan, *state) {
+		plan.Password = types.StringUnknown()
+		plan.PasswordRotatedAt = types.StringUnknown()
+	} else {
+		plan.Password = state.Password
+		plan.PasswordRotatedAt = state.PasswordRotatedAt
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// passwordNeedsRotation reports whether a new password must be generated
+func passwordNeedsRotation(plan Role, state Role) bool {
+	// The password was not generated, or was changed or removed out of band
+	if state.GeneratePassword.IsNull() || state.Password.ValueString() == "" {
+		return true
+	}
+
+	if !plan.GeneratePassword.Equal(state.GeneratePassword) || !plan.RotationTriggers.Equal(state.RotationTriggers) {
+		return true
+	}
+
+	if !plan.RotateAfter.IsNull() {
+		rotateAfter, err := time.ParseDuration(plan.RotateAfter.ValueString())
+		if err != nil {
+			return false
+		}
+
+		rotatedAt, err := time.Parse(time.RFC3339, state.PasswordRotatedAt.ValueString())
+		if err != nil || time.Since(rotatedAt) >= rotateAfter {
+			return true
+		}
 	}
+
+	return false
+}
+
+// generatePassword fills in the role's password from its generate_password
+// settings if the plan left it unknown
+func generatePassword(ctx context.Context, role *Role) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if role.GeneratePassword.IsNull() || !role.Password.IsUnknown() {
+		return diags
+	}
+
+	var settings GeneratePassword
+	diags.Append(role.GeneratePassword.As(ctx, &settings, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return diags
+	}
+
+	length := defaultPasswordLength
+	if !settings.Length.IsNull() {
+		length = int(settings.Length.ValueInt64())
+	}
+
+	boolOrDefault := func(value types.Bool, defaultValue bool) bool {
+		if value.IsNull() {
+			return defaultValue
+		}
+		return value.ValueBool()
+	}
+
+	password, err := utils.GeneratePassword(length, utils.PasswordClasses{
+		Lower:   boolOrDefault(settings.Lower, true),
+		Upper:   boolOrDefault(settings.Upper, true),
+		Numeric: boolOrDefault(settings.Numeric, true),
+		Special: boolOrDefault(settings.Special, false),
+	})
+	if err != nil {
+		diags.AddError(
+			"Password generation error",
+			err.Error(),
+		)
+		return diags
+	}
+
+	role.Password = types.StringValue(password)
+	role.PasswordRotatedAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+
+	return diags
 }
 
 func (r *resourceRole) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
@@ -81,9 +367,19 @@ func (r *resourceRole) Configure(_ context.Context, req resource.ConfigureReques
 	r.p = req.ProviderData.(*cockroachdbProvider)
 }
 
+// withPassword appends a PASSWORD clause to query. It also returns the
+// statement with the password redacted, which is the one to log.
+func withPassword(query string, password string) (string, string) {
+	return fmt.Sprintf(`%s PASSWORD %s`, query, pq.QuoteLiteral(password)),
+		fmt.Sprintf(`%s PASSWORD '***'`, query)
+}
+
 // Create a new resource
 func (r *resourceRole) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan Role
+	var (
+		plan       Role
+		passwordWO types.String
+	)
 
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -91,6 +387,13 @@ func (r *resourceRole) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	// Write-only values are only available in the config
+	diags = req.Config.GetAttribute(ctx, path.Root("password_wo"), &passwordWO)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Connect to db
 	conn, err := r.p.Conn(ctx, "")
 	if err != nil {
@@ -101,32 +404,36 @@ func (r *resourceRole) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	// Build query
-	// Why Go, why don't you have ternaries :'(
-	createRoleQuery := fmt.Sprintf(`CREATE ROLE "%s" WITH`, plan.Name.ValueString())
-	if !plan.CreateDatabase.ValueBool() || plan.CreateDatabase.IsNull() { // Default false
-		createRoleQuery += " NOCREATEDB"
-	} else {
-		createRoleQuery += " CREATEDB"
-	}
-	if !plan.CreateRole.ValueBool() || plan.CreateRole.IsNull() { // Default true
-		createRoleQuery += " NOCREATEROLE"
-	} else {
-		createRoleQuery += " CREATEROLE"
+	diags = generatePassword(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if !plan.Login.ValueBool() || plan.Login.IsNull() { // Default false
-		createRoleQuery += " NOLOGIN"
-	} else {
-		createRoleQuery += " LOGIN"
+
+	// The generated password must never show up in the provider logs
+	if !plan.GeneratePassword.IsNull() && plan.Password.ValueString() != "" {
+		ctx = tflog.MaskMessageStrings(ctx, plan.Password.ValueString())
 	}
-	if plan.Password.ValueString() != "" {
-		createRoleQuery = fmt.Sprintf(`%s PASSWORD '%s'`, createRoleQuery, plan.Password.ValueString())
+
+	// Build query
+	createRoleQuery := fmt.Sprintf(`CREATE ROLE "%s" WITH%s%s`, plan.Name.ValueString(), getRoleOptionsQuery(plan, nil), getValidUntilQuery(plan, nil))
+	loggedQuery := createRoleQuery
+	if passwordWO.ValueString() != "" {
+		createRoleQuery, loggedQuery = withPassword(createRoleQuery, passwordWO.ValueString())
+	} else if plan.Password.ValueString() != "" {
+		createRoleQuery, loggedQuery = withPassword(createRoleQuery, plan.Password.ValueString())
 	}
 
-	tflog.Info(ctx, createRoleQuery)
+	tflog.Info(ctx, loggedQuery)
+
+	// Create the role and its memberships in one transaction
+	err = executeInTx(ctx, conn, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, createRoleQuery); err != nil {
+			return err
+		}
 
-	// Run query
-	_, err = conn.Exec(ctx, createRoleQuery)
+		return setRoleMemberships(ctx, tx, plan, []string{})
+	})
 
 	// Error handling
 	if err != nil {
@@ -152,6 +459,12 @@ func (r *resourceRole) Create(ctx context.Context, req resource.CreateRequest, r
 	// Set the ID of the role
 	plan.ID = types.StringValue(newRole["name"].(string))
 
+	diags = storePasswordFingerprint(ctx, conn, plan.Name.ValueString(), resp.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	diags = resp.State.Set(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -159,20 +472,25 @@ func (r *resourceRole) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 }
 
-func GetRoleByKeyValue(conn *pgx.Conn, ctx context.Context, searchKey string, searchValue string) (map[string]interface{}, error) {
+// roleInfoQuery selects the columns scanned by scanRoleInfo
+const roleInfoQuery = `SELECT rolname, rolcreaterole, rolcreatedb, rolcanlogin, rolvaliduntil FROM pg_roles`
+
+// scanRoleInfo maps a row of roleInfoQuery onto the role row map used by
+// readRoleOptions
+func scanRoleInfo(row pgx.Row) (map[string]interface{}, error) {
 	var (
 		rolname       string
 		rolcreaterole bool
 		rolcreatedb   bool
 		rolcanlogin   bool
+		rolvaliduntil pgtype.Timestamptz
 	)
-	selectQuery := fmt.Sprintf(`SELECT rolname, rolcreaterole, rolcreatedb, rolcanlogin FROM pg_roles WHERE %s = '%s'`, searchKey, searchValue)
-	tflog.Info(ctx, selectQuery)
-	err := conn.QueryRow(ctx, selectQuery).Scan(
+	err := row.Scan(
 		&rolname,
 		&rolcreaterole,
 		&rolcreatedb,
 		&rolcanlogin,
+		&rolvaliduntil,
 	)
 
 	if err != nil {
@@ -184,9 +502,252 @@ func GetRoleByKeyValue(conn *pgx.Conn, ctx context.Context, searchKey string, se
 		"create_role":     rolcreaterole,
 		"create_database": rolcreatedb,
 		"login":           rolcanlogin,
+		"valid_until":     rolvaliduntil,
 	}, nil
 }
 
+func GetRoleByKeyValue(conn *pgx.Conn, ctx context.Context, searchKey string, searchValue string) (map[string]interface{}, error) {
+	selectQuery := fmt.Sprintf(`%s WHERE %s = $1`, roleInfoQuery, pq.QuoteIdentifier(searchKey))
+	tflog.Info(ctx, selectQuery)
+
+	return scanRoleInfo(conn.QueryRow(ctx, selectQuery, searchValue))
+}
+
+// roleOption ties a CockroachDB role option to the attribute that manages it
+type roleOption struct {
+	// name is the option keyword, e.g. CREATEDB
+	name string
+	// negation is the keyword that turns the option off, e.g. NOCREATEDB
+	negation string
+	// legacy options are always sent, newer options only when they are set
+	legacy bool
+	value  *types.Bool
+}
+
+// roleOptions returns the role options in the order they are sent to the server
+func (role *Role) roleOptions() []roleOption {
+	return []roleOption{
+		{name: "CREATEDB", negation: "NOCREATEDB", legacy: true, value: &role.CreateDatabase},
+		{name: "CREATEROLE", negation: "NOCREATEROLE", legacy: true, value: &role.CreateRole},
+		{name: "LOGIN", negation: "NOLOGIN", legacy: true, value: &role.Login},
+		{name: "CONTROLJOB", negation: "NOCONTROLJOB", value: &role.ControlJob},
+		{name: "CONTROLCHANGEFEED", negation: "NOCONTROLCHANGEFEED", value: &role.ControlChangefeed},
+		{name: "VIEWACTIVITY", negation: "NOVIEWACTIVITY", value: &role.ViewActivity},
+		{name: "VIEWACTIVITYREDACTED", negation: "NOVIEWACTIVITYREDACTED", value: &role.ViewActivityRedacted},
+		{name: "CANCELQUERY", negation: "NOCANCELQUERY", value: &role.CancelQuery},
+		{name: "MODIFYCLUSTERSETTING", negation: "NOMODIFYCLUSTERSETTING", value: &role.ModifyClusterSetting},
+		{name: "VIEWCLUSTERSETTING", negation: "NOVIEWCLUSTERSETTING", value: &role.ViewClusterSetting},
+		{name: "NOSQLLOGIN", negation: "SQLLOGIN", value: &role.NoSqlLogin},
+		{name: "CREATELOGIN", negation: "NOCREATELOGIN", value: &role.CreateLogin},
+		{name: "REPLICATION", negation: "NOREPLICATION", value: &role.Replication},
+	}
+}
+
+// getRoleOptionsQuery builds the option list of a CREATE or ALTER ROLE
+// statement. Non legacy options are only sent when they are set in plan, or
+// were set in state and need to be turned off again.
+func getRoleOptionsQuery(plan Role, state *Role) string {
+	var stateOptions []roleOption
+	if state != nil {
+		stateOptions = state.roleOptions()
+	}
+
+	query := ""
+	for i, option := range plan.roleOptions() {
+		if !option.legacy && option.value.IsNull() && (stateOptions == nil || stateOptions[i].value.IsNull()) {
+			continue
+		}
+
+		if option.value.ValueBool() {
+			query += " " + option.name
+		} else {
+			query += " " + option.negation
+		}
+	}
+
+	return query
+}
+
+// getValidUntilQuery builds the VALID UNTIL clause of a CREATE or ALTER ROLE
+// statement. It is cleared with NULL when it is removed from the plan.
+func getValidUntilQuery(plan Role, state *Role) string {
+	if !plan.ValidUntil.IsNull() {
+		return fmt.Sprintf(" VALID UNTIL %s", pq.QuoteLiteral(plan.ValidUntil.ValueString()))
+	}
+	if state != nil && !state.ValidUntil.IsNull() {
+		return " VALID UNTIL NULL"
+	}
+	return ""
+}
+
+// readValidUntil converts pg_roles.rolvaliduntil to the valid_until attribute.
+// The current value is kept when it is the same instant in another format.
+func readValidUntil(validUntil pgtype.Timestamptz, current types.String) types.String {
+	if !validUntil.Valid {
+		return types.StringNull()
+	}
+
+	if validUntil.InfinityModifier == pgtype.Infinity {
+		return types.StringValue("infinity")
+	}
+
+	if currentTime, err := time.Parse(time.RFC3339, current.ValueString()); err == nil && currentTime.Equal(validUntil.Time) {
+		return current
+	}
+
+	return types.StringValue(validUntil.Time.UTC().Format(time.RFC3339))
+}
+
+// readRoleOptions returns which role options are turned on. The legacy options
+// come from pg_roles, the rest from system.role_options.
+func readRoleOptions(ctx context.Context, conn dbExecutor, roleRow map[string]interface{}) (map[string]bool, error) {
+	options := map[string]bool{
+		"CREATEDB":   roleRow["create_database"].(bool),
+		"CREATEROLE": roleRow["create_role"].(bool),
+		"LOGIN":      roleRow["login"].(bool),
+	}
+
+	var option string
+	rows, err := conn.Query(ctx, `SELECT option FROM system.role_options WHERE username = $1`, roleRow["name"].(string))
+	if err != nil {
+		return nil, err
+	}
+	_, err = pgx.ForEachRow(rows, []any{&option}, func() error {
+		// LOGIN is already known from pg_roles
+		if option != "NOLOGIN" {
+			options[option] = true
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return options, nil
+}
+
+// defaultPasswordLength is the length of generated passwords unless configured
+const defaultPasswordLength = 32
+
+// passwordFingerprintKey is the private state key holding the fingerprint of
+// the password hash last written by terraform
+const passwordFingerprintKey = "password_fingerprint"
+
+// privateStateSetter is implemented by the private state of create, read and
+// update responses
+type privateStateSetter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// passwordFingerprint is a salted digest of system.users.hashedPassword. The
+// hash changes whenever the password is set, so comparing fingerprints shows
+// if the password was changed outside of terraform without keeping the hash
+// itself in state.
+type passwordFingerprint struct {
+	Salt        string `json:"salt"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+func newPasswordFingerprint(hashedPassword []byte) (passwordFingerprint, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return passwordFingerprint{}, err
+	}
+
+	fingerprint := passwordFingerprint{Salt: hex.EncodeToString(salt)}
+	fingerprint.Fingerprint = fingerprint.digest(hashedPassword)
+
+	return fingerprint, nil
+}
+
+func (f passwordFingerprint) digest(hashedPassword []byte) string {
+	digest := sha256.Sum256(append([]byte(f.Salt), hashedPassword...))
+	return hex.EncodeToString(digest[:])
+}
+
+func (f passwordFingerprint) matches(hashedPassword []byte) bool {
+	return subtle.ConstantTimeCompare([]byte(f.digest(hashedPassword)), []byte(f.Fingerprint)) == 1
+}
+
+func readHashedPassword(ctx context.Context, conn dbExecutor, name string) ([]byte, error) {
+	var hashedPassword []byte
+	err := conn.QueryRow(ctx, `SELECT "hashedPassword" FROM system.users WHERE username = $1`, name).Scan(&hashedPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	return hashedPassword, nil
+}
+
+// storePasswordFingerprint saves the fingerprint of the role's current
+// password hash in private state, or clears it when there is no password
+func storePasswordFingerprint(ctx context.Context, conn dbExecutor, name string, private privateStateSetter) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	hashedPassword, err := readHashedPassword(ctx, conn, name)
+	if err != nil {
+		diags.AddError(
+			"Cockroach execute sql error",
+			err.Error(),
+		)
+		return diags
+	}
+
+	value := []byte("null")
+	if len(hashedPassword) > 0 {
+		fingerprint, err := newPasswordFingerprint(hashedPassword)
+		if err != nil {
+			diags.AddError(
+				"Password fingerprint error",
+				err.Error(),
+			)
+			return diags
+		}
+
+		value, err = json.Marshal(fingerprint)
+		if err != nil {
+			diags.AddError(
+				"Password fingerprint error",
+				err.Error(),
+			)
+			return diags
+		}
+	}
+
+	return private.SetKey(ctx, passwordFingerprintKey, value)
+}
+
+func roleExists(ctx context.Context, conn dbExecutor, name string) (bool, error) {
+	var exists bool
+	err := conn.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = $1)`, name).Scan(&exists)
+	return exists, err
+}
+
+// repointRenamedRole returns the role name dependent resources should use for
+// the role in state. When a cockroachdb_role is renamed in place its grants
+// and memberships move with it, so if the role in state no longer exists but
+// the planned one does, the planned name is returned.
+func repointRenamedRole(ctx context.Context, conn dbExecutor, stateName types.String, planName types.String) (types.String, error) {
+	if stateName.ValueString() == planName.ValueString() {
+		return stateName, nil
+	}
+
+	stateExists, err := roleExists(ctx, conn, stateName.ValueString())
+	if err != nil || stateExists {
+		return stateName, err
+	}
+
+	planExists, err := roleExists(ctx, conn, planName.ValueString())
+	if err != nil || !planExists {
+		return stateName, err
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Role %s was renamed to %s", stateName.ValueString(), planName.ValueString()))
+
+	return planName, nil
+}
+
 // Read resource information
 func (r *resourceRole) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var state Role
@@ -220,14 +781,92 @@ func (r *resourceRole) Read(ctx context.Context, req resource.ReadRequest, resp
 	// Set the state object
 	state.ID = types.StringValue(roleRow["name"].(string))
 	state.Name = types.StringValue(roleRow["name"].(string))
-	if roleRow["create_database"].(bool) {
-		state.CreateDatabase = types.BoolValue(true)
+
+	state.ValidUntil = readValidUntil(roleRow["valid_until"].(pgtype.Timestamptz), state.ValidUntil)
+
+	// Detect passwords that were set, changed or removed outside of terraform
+	hashedPassword, err := readHashedPassword(ctx, conn, state.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach execute sql error",
+			err.Error(),
+		)
+		return
+	}
+
+	fingerprintJSON, diags := req.Private.GetKey(ctx, passwordFingerprintKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if roleRow["create_role"].(bool) {
-		state.CreateRole = types.BoolValue(true)
+	var fingerprint *passwordFingerprint
+	if len(fingerprintJSON) > 0 {
+		if err := json.Unmarshal(fingerprintJSON, &fingerprint); err != nil {
+			resp.Diagnostics.AddError(
+				"Password fingerprint error",
+				err.Error(),
+			)
+			return
+		}
 	}
-	if roleRow["login"].(bool) {
-		state.Login = types.BoolValue(true)
+
+	passwordChanged := len(hashedPassword) == 0 || (fingerprint != nil && !fingerprint.matches(hashedPassword))
+	passwordManaged := !state.PasswordWOVersion.IsNull() || state.Password.ValueString() != ""
+	if !state.PasswordWOVersion.IsNull() && passwordChanged {
+		// The write-only password was removed or changed out of band, clear
+		// the version so the next apply sets it again
+		state.PasswordWOVersion = types.Int64Null()
+	} else if !passwordManaged {
+		// A password was set out of band, an empty password plans its removal
+		if len(hashedPassword) > 0 {
+			state.Password = types.StringValue("")
+		}
+	} else if passwordChanged {
+		// The password was removed or changed out of band, plan setting it again
+		state.Password = types.StringNull()
+	} else if fingerprint == nil {
+		// Start tracking resources created before fingerprints were stored
+		diags = storePasswordFingerprint(ctx, conn, state.Name.ValueString(), resp.Private)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if !state.MemberOf.IsNull() {
+		memberOf, err := readRoleMemberships(ctx, conn, state.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Cockroach execute sql error",
+				err.Error(),
+			)
+			return
+		}
+
+		state.MemberOf, diags = types.SetValueFrom(ctx, types.StringType, memberOf)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	options, err := readRoleOptions(ctx, conn, roleRow)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach execute sql error",
+			err.Error(),
+		)
+		return
+	}
+
+	// Options that are turned off are only reported when they are managed, so
+	// an unset attribute and false are equivalent
+	for _, option := range state.roleOptions() {
+		if options[option.name] {
+			*option.value = types.BoolValue(true)
+		} else if !option.value.IsNull() {
+			*option.value = types.BoolValue(false)
+		}
 	}
 
 	// Set state
@@ -241,8 +880,9 @@ func (r *resourceRole) Read(ctx context.Context, req resource.ReadRequest, resp
 // Update resource
 func (r *resourceRole) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var (
-		state Role
-		plan  Role
+		state      Role
+		plan       Role
+		passwordWO types.String
 	)
 
 	diags := req.State.Get(ctx, &state)
@@ -257,6 +897,13 @@ func (r *resourceRole) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	// Write-only values are only available in the config
+	diags = req.Config.GetAttribute(ctx, path.Root("password_wo"), &passwordWO)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Connect to db
 	conn, err := r.p.Conn(ctx, "")
 	if err != nil {
@@ -267,42 +914,62 @@ func (r *resourceRole) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	if state.Name.ValueString() != plan.Name.ValueString() {
-		resp.Diagnostics.AddError(
-			"Terraform config error",
-			"Role name cannot be updated in cockroachdb, create new roles",
-		)
+	diags = generatePassword(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Build query
-	// Why Go, why don't you have ternaries :'(
-	alterRoleQuery := fmt.Sprintf(`ALTER ROLE "%s" WITH`, plan.Name.ValueString())
-	if !plan.CreateDatabase.ValueBool() || plan.CreateDatabase.IsNull() { // Default false
-		alterRoleQuery += " NOCREATEDB"
-	} else {
-		alterRoleQuery += " CREATEDB"
+	// The generated password must never show up in the provider logs
+	if !plan.GeneratePassword.IsNull() && plan.Password.ValueString() != "" {
+		ctx = tflog.MaskMessageStrings(ctx, plan.Password.ValueString())
 	}
-	if !plan.CreateRole.ValueBool() || plan.CreateRole.IsNull() { // Default true
-		alterRoleQuery += " NOCREATEROLE"
-	} else {
-		alterRoleQuery += " CREATEROLE"
-	}
-	if !plan.Login.ValueBool() || plan.Login.IsNull() { // Default false
-		alterRoleQuery += " NOLOGIN"
-	} else {
-		alterRoleQuery += " LOGIN"
-	}
-	if plan.Password.ValueString() == "" || plan.Password.IsNull() { // Default false
+
+	// Build query
+	alterRoleQuery := fmt.Sprintf(`ALTER ROLE "%s" WITH%s%s`, plan.Name.ValueString(), getRoleOptionsQuery(plan, &state), getValidUntilQuery(plan, &state))
+	loggedQuery := alterRoleQuery
+	if !plan.PasswordWOVersion.IsNull() {
+		// A write-only password is left alone until its version changes
+		if !plan.PasswordWOVersion.Equal(state.PasswordWOVersion) {
+			alterRoleQuery, loggedQuery = withPassword(alterRoleQuery, passwordWO.ValueString())
+		}
+	} else if plan.Password.ValueString() == "" || plan.Password.IsNull() { // Default false
 		alterRoleQuery = fmt.Sprintf(`%s PASSWORD %s`, alterRoleQuery, "null")
+		loggedQuery = alterRoleQuery
 	} else {
-		alterRoleQuery = fmt.Sprintf(`%s PASSWORD '%s'`, alterRoleQuery, plan.Password.ValueString())
+		alterRoleQuery, loggedQuery = withPassword(alterRoleQuery, plan.Password.ValueString())
 	}
 
-	tflog.Info(ctx, alterRoleQuery)
+	// Rename in place so grants and memberships follow the role, then alter it
+	// under its new name in the same transaction
+	err = executeInTx(ctx, conn, func(tx pgx.Tx) error {
+		if state.Name.ValueString() != plan.Name.ValueString() {
+			renameRoleQuery := fmt.Sprintf(`ALTER ROLE %s RENAME TO %s`, pq.QuoteIdentifier(state.Name.ValueString()), pq.QuoteIdentifier(plan.Name.ValueString()))
+			tflog.Info(ctx, renameRoleQuery)
+
+			if _, err := tx.Exec(ctx, renameRoleQuery); err != nil {
+				return err
+			}
+		}
+
+		tflog.Info(ctx, loggedQuery)
+
+		if _, err := tx.Exec(ctx, alterRoleQuery); err != nil {
+			return err
+		}
+
+		// Memberships are left alone when member_of isn't managed
+		if plan.MemberOf.IsNull() {
+			return nil
+		}
+
+		memberOf, err := readRoleMemberships(ctx, tx, plan.Name.ValueString())
+		if err != nil {
+			return err
+		}
 
-	// Run query
-	_, err = conn.Exec(ctx, alterRoleQuery)
+		return setRoleMemberships(ctx, tx, plan, memberOf)
+	})
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -312,13 +979,29 @@ func (r *resourceRole) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	diags = storePasswordFingerprint(ctx, conn, plan.Name.ValueString(), resp.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Set the state object
 	state.ID = plan.Name
 	state.Name = plan.Name
-	state.CreateDatabase = plan.CreateDatabase
-	state.CreateRole = plan.CreateRole
-	state.Login = plan.Login
 	state.Password = plan.Password
+	state.PasswordWOVersion = plan.PasswordWOVersion
+	state.GeneratePassword = plan.GeneratePassword
+	state.RotationTriggers = plan.RotationTriggers
+	state.RotateAfter = plan.RotateAfter
+	state.PasswordRotatedAt = plan.PasswordRotatedAt
+	state.ValidUntil = plan.ValidUntil
+	state.MemberOf = plan.MemberOf
+	state.ReassignOwnedTo = plan.ReassignOwnedTo
+	state.DropOwned = plan.DropOwned
+	stateOptions := state.roleOptions()
+	for i, option := range plan.roleOptions() {
+		*stateOptions[i].value = *option.value
+	}
 
 	// Set state
 	diags = resp.State.Set(ctx, &state)
@@ -348,6 +1031,15 @@ func (r *resourceRole) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	err = r.releaseOwned(ctx, conn, state)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach execute sql error",
+			err.Error(),
+		)
+		return
+	}
+
 	_, err = conn.Exec(ctx, fmt.Sprintf(`DROP ROLE "%s"`, state.Name.ValueString()))
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -368,16 +1060,290 @@ func (r *resourceRole) Delete(ctx context.Context, req resource.DeleteRequest, r
 	}
 }
 
+// readRoleMemberships returns the names of the roles the role is a member of
+func readRoleMemberships(ctx context.Context, conn dbExecutor, role string) ([]string, error) {
+	rows, err := conn.Query(ctx, `SELECT "role" FROM system.role_members WHERE member = $1 ORDER BY "role"`, role)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+// setRoleMemberships grants the memberships in member_of the role is missing
+// and revokes the ones it has that aren't listed
+func setRoleMemberships(ctx context.Context, conn dbExecutor, role Role, current []string) error {
+	if role.MemberOf.IsNull() {
+		return nil
+	}
+
+	var memberOf []string
+	if diags := role.MemberOf.ElementsAs(ctx, &memberOf, false); diags.HasError() {
+		return fmt.Errorf("reading member_of: %v", diags)
+	}
+
+	wanted := map[string]bool{}
+	for _, parent := range memberOf {
+		wanted[parent] = true
+	}
+
+	for _, parent := range current {
+		if wanted[parent] {
+			delete(wanted, parent)
+			continue
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("REVOKE %s FROM %s", parent, role.Name.ValueString()))
+		if err := DeleteGrantRole(ctx, conn, GrantRole{Role: types.StringValue(parent), User: role.Name}); err != nil {
+			return err
+		}
+	}
+
+	for _, parent := range memberOf {
+		if !wanted[parent] {
+			continue
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("GRANT %s TO %s", parent, role.Name.ValueString()))
+		if err := CreateGrantRole(ctx, conn, &GrantRole{Role: types.StringValue(parent), User: role.Name}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// listDatabases returns the databases REASSIGN OWNED and DROP OWNED have to
+// run in, as both only affect the current database
+func listDatabases(ctx context.Context, conn dbExecutor) ([]string, error) {
+	rows, err := conn.Query(ctx, `SELECT name FROM crdb_internal.databases WHERE name != 'system' ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+// releaseOwned reassigns and drops what the role owns in every database so
+// that it can be dropped
+func (r *resourceRole) releaseOwned(ctx context.Context, conn *pgx.Conn, state Role) error {
+	if state.ReassignOwnedTo.IsNull() && !state.DropOwned.ValueBool() {
+		return nil
+	}
+
+	databases, err := listDatabases(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	for _, database := range databases {
+		dbConn, err := r.p.Conn(ctx, database)
+		if err != nil {
+			return err
+		}
+
+		queries := []string{}
+		if !state.ReassignOwnedTo.IsNull() {
+			queries = append(queries, fmt.Sprintf("REASSIGN OWNED BY %s TO %s", pq.QuoteIdentifier(state.Name.ValueString()), pq.QuoteIdentifier(state.ReassignOwnedTo.ValueString())))
+		}
+		if state.DropOwned.ValueBool() {
+			queries = append(queries, fmt.Sprintf("DROP OWNED BY %s", pq.QuoteIdentifier(state.Name.ValueString())))
+		}
+
+		for _, query := range queries {
+			tflog.Info(ctx, fmt.Sprintf("%s (database %s)", query, database))
+
+			if _, err = dbConn.Exec(ctx, query); err != nil {
+				break
+			}
+		}
+
+		dbConn.Close(ctx)
+		if err != nil {
+			return fmt.Errorf("database %s: %w", database, err)
+		}
+	}
+
+	return nil
+}
+
+// readRoleDependencies describes the objects the role owns and the privileges
+// it holds in the database conn is connected to
+func readRoleDependencies(ctx context.Context, conn dbExecutor, role string) ([]string, error) {
+	dependencies := []string{}
+
+	rows, err := conn.Query(ctx, `
+		SELECT 'owner of schema ' || n.nspname
+		FROM pg_catalog.pg_namespace n
+		JOIN pg_catalog.pg_roles r ON r.oid = n.nspowner
+		WHERE r.rolname = $1
+		UNION ALL
+		SELECT 'owner of ' || CASE c.relkind WHEN 'S' THEN 'sequence' WHEN 'v' THEN 'view' WHEN 'm' THEN 'materialized view' ELSE 'table' END || ' ' || n.nspname || '.' || c.relname
+		FROM pg_catalog.pg_class c
+		JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+		JOIN pg_catalog.pg_roles r ON r.oid = c.relowner
+		WHERE r.rolname = $1 AND c.relkind IN ('r', 'v', 'm', 'S')`,
+		role,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	owned, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, err
+	}
+	dependencies = append(dependencies, owned...)
+
+	var database string
+	if err := conn.QueryRow(ctx, `SELECT current_database()`).Scan(&database); err != nil {
+		return nil, err
+	}
+
+	rows, err = conn.Query(ctx, fmt.Sprintf(`SHOW GRANTS FOR %s`, pq.QuoteIdentifier(role)))
+	if err != nil {
+		return nil, err
+	}
+
+	// The object column differs between versions, see readObjectPrivileges
+	grantRows, err := pgx.CollectRows(rows, pgx.RowToMap)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range grantRows {
+		if grantColumn(row, "database_name") != database {
+			continue
+		}
+
+		schemaName := grantColumn(row, "schema_name")
+		objectName := grantColumn(row, "object_name", "relation_name")
+
+		on := "database " + database
+		if objectName != "" {
+			on = schemaName + "." + objectName
+		} else if schemaName != "" {
+			on = "schema " + schemaName
+		}
+		dependencies = append(dependencies, grantColumn(row, "privilege_type")+" on "+on)
+	}
+
+	return dependencies, nil
+}
+
+// warnRoleDependencies adds a warning listing what reassign_owned_to and
+// drop_owned affect when the role is deleted
+func (r *resourceRole) warnRoleDependencies(ctx context.Context, state Role, diags *diag.Diagnostics) {
+	if state.ReassignOwnedTo.IsNull() && !state.DropOwned.ValueBool() {
+		return
+	}
+
+	conn, err := r.p.Conn(ctx, "")
+	if err != nil {
+		diags.AddError(
+			"Cockroach connection error",
+			err.Error(),
+		)
+		return
+	}
+	defer conn.Close(ctx)
+
+	databases, err := listDatabases(ctx, conn)
+	if err != nil {
+		diags.AddError(
+			"Cockroach execute sql error",
+			err.Error(),
+		)
+		return
+	}
+
+	lines := []string{}
+	for _, database := range databases {
+		dbConn, err := r.p.Conn(ctx, database)
+		if err != nil {
+			diags.AddError(
+				"Cockroach connection error",
+				err.Error(),
+			)
+			return
+		}
+
+		dependencies, err := readRoleDependencies(ctx, dbConn, state.Name.ValueString())
+		dbConn.Close(ctx)
+		if err != nil {
+			diags.AddError(
+				"Cockroach execute sql error",
+				err.Error(),
+			)
+			return
+		}
+
+		for _, dependency := range dependencies {
+			lines = append(lines, fmt.Sprintf("  - %s: %s", database, dependency))
+		}
+	}
+
+	if len(lines) == 0 {
+		return
+	}
+
+	actions := []string{}
+	if !state.ReassignOwnedTo.IsNull() {
+		actions = append(actions, fmt.Sprintf("objects it owns are reassigned to %q", state.ReassignOwnedTo.ValueString()))
+	}
+	if state.DropOwned.ValueBool() {
+		actions = append(actions, "objects it still owns are dropped and its privileges revoked")
+	}
+
+	diags.AddWarning(
+		"Role dependencies will be affected",
+		fmt.Sprintf(
+			"Before role %q is dropped, %s:\n%s",
+			state.Name.ValueString(),
+			strings.Join(actions, ", then "),
+			strings.Join(lines, "\n"),
+		),
+	)
+}
+
 func (r *resourceRole) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	// Retrieve import ID and save to id attribute
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
 type Role struct {
-	Name           types.String `tfsdk:"name"`
-	Password       types.String `tfsdk:"password"`
-	CreateDatabase types.Bool   `tfsdk:"create_database"`
-	CreateRole     types.Bool   `tfsdk:"create_role"`
-	Login          types.Bool   `tfsdk:"login"`
-	ID             types.String `tfsdk:"id"`
+	Name                 types.String `tfsdk:"name"`
+	Password             types.String `tfsdk:"password"`
+	PasswordWO           types.String `tfsdk:"password_wo"`
+	PasswordWOVersion    types.Int64  `tfsdk:"password_wo_version"`
+	CreateDatabase       types.Bool   `tfsdk:"create_database"`
+	CreateRole           types.Bool   `tfsdk:"create_role"`
+	Login                types.Bool   `tfsdk:"login"`
+	ControlJob           types.Bool   `tfsdk:"control_job"`
+	ControlChangefeed    types.Bool   `tfsdk:"control_changefeed"`
+	ViewActivity         types.Bool   `tfsdk:"view_activity"`
+	ViewActivityRedacted types.Bool   `tfsdk:"view_activity_redacted"`
+	CancelQuery          types.Bool   `tfsdk:"cancel_query"`
+	ModifyClusterSetting types.Bool   `tfsdk:"modify_cluster_setting"`
+	ViewClusterSetting   types.Bool   `tfsdk:"view_cluster_setting"`
+	NoSqlLogin           types.Bool   `tfsdk:"no_sql_login"`
+	CreateLogin          types.Bool   `tfsdk:"create_login"`
+	Replication          types.Bool   `tfsdk:"replication"`
+	ValidUntil           types.String `tfsdk:"valid_until"`
+	GeneratePassword     types.Object `tfsdk:"generate_password"`
+	RotationTriggers     types.Map    `tfsdk:"rotation_triggers"`
+	RotateAfter          types.String `tfsdk:"rotate_after"`
+	PasswordRotatedAt    types.String `tfsdk:"password_rotated_at"`
+	MemberOf             types.Set    `tfsdk:"member_of"`
+	ReassignOwnedTo      types.String `tfsdk:"reassign_owned_to"`
+	DropOwned            types.Bool   `tfsdk:"drop_owned"`
+	ID                   types.String `tfsdk:"id"`
+}
+
+type GeneratePassword struct {
+	Length  types.Int64 `tfsdk:"length"`
+	Lower   types.Bool  `tfsdk:"lower"`
+	Upper   types.Bool  `tfsdk:"upper"`
+	Numeric types.Bool  `tfsdk:"numeric"`
+	Special types.Bool  `tfsdk:"special"`
 }
@@ -0,0 +1,404 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jackc/pgx/v5"
+	"github.com/lib/pq"
+)
+
+// allRoles is the special role name that targets every role on the cluster
+const allRoles = "ALL"
+
+// isAllRoles reports whether role targets every role. Like a keyword, `ALL`
+// is matched in any case.
+func isAllRoles(role string) bool {
+	return strings.EqualFold(role, allRoles)
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &resourceRoleSetting{}
+	_ resource.ResourceWithConfigure   = &resourceRoleSetting{}
+	_ resource.ResourceWithImportState = &resourceRoleSetting{}
+)
+
+func NewRoleSettingResource() resource.Resource {
+	return &resourceRoleSetting{}
+}
+
+type resourceRoleSetting struct {
+	p *cockroachdbProvider
+}
+
+func (r *resourceRoleSetting) Metadata(_ context.Context, _ resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = "cockroachdb_role_setting"
+}
+
+func (r *resourceRoleSetting) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a default session variable for a role, optionally scoped to a database.",
+		Attributes: map[string]schema.Attribute{
+			"role": schema.StringAttribute{
+				Description: "Target role name. Use `ALL`, in any case, to set the default for every role.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"database": schema.StringAttribute{
+				Description: "Target database name. When omitted the default applies in every database.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"variable": schema.StringAttribute{
+				Description: "Name of the session variable, e.g. statement_timeout",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"value": schema.StringAttribute{
+				Description: "Default value of the session variable",
+				Required:    true,
+			},
+			"id": schema.StringAttribute{
+				Description: "ID of the role setting",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *resourceRoleSetting) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.p = req.ProviderData.(*cockroachdbProvider)
+}
+
+// getRoleSettingTarget returns the `ALTER ROLE` target for the setting, e.g.
+// `"app" IN DATABASE "appdb"` or `ALL`
+func getRoleSettingTarget(setting RoleSetting) string {
+	target := allRoles
+	if !isAllRoles(setting.Role.ValueString()) {
+		target = pq.QuoteIdentifier(setting.Role.ValueString())
+	}
+
+	if setting.Database.ValueString() != "" {
+		target = fmt.Sprintf("%s IN DATABASE %s", target, pq.QuoteIdentifier(setting.Database.ValueString()))
+	}
+
+	return target
+}
+
+func setRoleSetting(ctx context.Context, conn *pgx.Conn, setting *RoleSetting) error {
+	query := fmt.Sprintf(
+		"ALTER ROLE %s SET %s = %s",
+		getRoleSettingTarget(*setting),
+		pq.QuoteIdentifier(setting.Variable.ValueString()),
+		pq.QuoteLiteral(setting.Value.ValueString()),
+	)
+
+	tflog.Info(ctx, query)
+
+	_, err := conn.Exec(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	// Generate "ID" from setting
+	setting.ID = types.StringValue(setting.Role.ValueString() + "|" + setting.Database.ValueString() + "|" + setting.Variable.ValueString())
+
+	return nil
+}
+
+func resetRoleSetting(ctx context.Context, conn *pgx.Conn, setting RoleSetting) error {
+	query := fmt.Sprintf(
+		"ALTER ROLE %s RESET %s",
+		getRoleSettingTarget(setting),
+		pq.QuoteIdentifier(setting.Variable.ValueString()),
+	)
+
+	tflog.Info(ctx, query)
+
+	_, err := conn.Exec(ctx, query)
+	return err
+}
+
+// readDbRoleSettings returns the session defaults stored in
+// pg_db_role_setting for a role and database. A setting for ALL roles is
+// stored with a role oid of 0 and a setting for every database with a
+// database oid of 0, so both are matched with an empty name.
+func readDbRoleSettings(ctx context.Context, conn *pgx.Conn, role string, database string) (map[string]string, error) {
+	rows, err := conn.Query(ctx, `
+		SELECT s.setconfig
+		FROM pg_catalog.pg_db_role_setting s
+		LEFT JOIN pg_catalog.pg_roles r ON r.oid = s.setrole
+		LEFT JOIN pg_catalog.pg_database d ON d.oid = s.setdatabase
+		WHERE COALESCE(r.rolname, '') = $1 AND COALESCE(d.datname, '') = $2`,
+		role,
+		database,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var setconfig []string
+	settings := map[string]string{}
+	_, err = pgx.ForEachRow(rows, []any{&setconfig}, func() error {
+		for _, config := range setconfig {
+			pieces := strings.SplitN(config, "=", 2)
+			if len(pieces) == 2 {
+				settings[pieces[0]] = pieces[1]
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+func readRoleSetting(ctx context.Context, conn *pgx.Conn, setting RoleSetting) (string, bool, error) {
+	role := setting.Role.ValueString()
+	if isAllRoles(role) {
+		role = ""
+	}
+
+	settings, err := readDbRoleSettings(ctx, conn, role, setting.Database.ValueString())
+	if err != nil {
+		return "", false, err
+	}
+
+	for variable, value := range settings {
+		if strings.EqualFold(variable, setting.Variable.ValueString()) {
+			return value, true, nil
+		}
+	}
+
+	return "", false, nil
+}
+
+// Create a new resource
+func (r *resourceRoleSetting) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan RoleSetting
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Connect to db
+	conn, err := r.p.Conn(ctx, "")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach connection error",
+			err.Error(),
+		)
+		return
+	}
+	defer conn.Close(ctx)
+
+	err = setRoleSetting(ctx, conn, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach execute sql error",
+			err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information
+func (r *resourceRoleSetting) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state RoleSetting
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// In cases where we are importing state from a single ID, parse the ID into the proper pieces
+	idPieces := strings.Split(state.ID.ValueString(), "|")
+	if len(idPieces) != 3 {
+		resp.Diagnostics.AddError(
+			"Invalid role setting ID",
+			fmt.Sprintf("Expected an ID of the form role|database|variable, got %q", state.ID.ValueString()),
+		)
+		return
+	}
+	state.Role = types.StringValue(idPieces[0])
+	if idPieces[1] != "" {
+		state.Database = types.StringValue(idPieces[1])
+	}
+	state.Variable = types.StringValue(idPieces[2])
+
+	// Connect to db
+	conn, err := r.p.Conn(ctx, "")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach connection error",
+			err.Error(),
+		)
+		return
+	}
+	defer conn.Close(ctx)
+
+	value, found, err := readRoleSetting(ctx, conn, state)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach execute sql error",
+			err.Error(),
+		)
+		return
+	}
+
+	// The setting was reset outside of terraform
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.Value = types.StringValue(value)
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update resource
+func (r *resourceRoleSetting) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan RoleSetting
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Connect to db
+	conn, err := r.p.Conn(ctx, "")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach connection error",
+			err.Error(),
+		)
+		return
+	}
+	defer conn.Close(ctx)
+
+	// Only the value can change in place, everything else forces a replacement
+	err = setRoleSetting(ctx, conn, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach execute sql error",
+			err.Error(),
+		)
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete resource
+func (r *resourceRoleSetting) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state RoleSetting
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Connect to db
+	conn, err := r.p.Conn(ctx, "")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach connection error",
+			err.Error(),
+		)
+		return
+	}
+	defer conn.Close(ctx)
+
+	// Settings move with a role that was renamed in place, in which case the
+	// old name no longer exists and there is nothing left to reset
+	if !isAllRoles(state.Role.ValueString()) {
+		exists, err := roleExists(ctx, conn, state.Role.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Cockroach execute sql error",
+				err.Error(),
+			)
+			return
+		}
+		if !exists {
+			return
+		}
+	}
+
+	err = resetRoleSetting(ctx, conn, state)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach execute sql error",
+			err.Error(),
+		)
+		return
+	}
+}
+
+func (r *resourceRoleSetting) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+type RoleSetting struct {
+	ID       types.String `tfsdk:"id"`
+	Role     types.String `tfsdk:"role"`
+	Database types.String `tfsdk:"database"`
+	Variable types.String `tfsdk:"variable"`
+	Value    types.String `tfsdk:"value"`
+}
@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccRoleSettingResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: prefixProvider(`
+resource "cockroachdb_role" "test_role" {
+  name = "test_role"
+}
+
+resource "cockroachdb_role_setting" "test_role_setting" {
+  role     = cockroachdb_role.test_role.name
+  database = "defaultdb"
+  variable = "statement_timeout"
+  value    = "10s"
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cockroachdb_role_setting.test_role_setting", "role", "test_role"),
+					resource.TestCheckResourceAttr("cockroachdb_role_setting.test_role_setting", "database", "defaultdb"),
+					resource.TestCheckResourceAttr("cockroachdb_role_setting.test_role_setting", "variable", "statement_timeout"),
+					resource.TestCheckResourceAttr("cockroachdb_role_setting.test_role_setting", "value", "10s"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "cockroachdb_role_setting.test_role_setting",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: prefixProvider(`
+resource "cockroachdb_role" "test_role" {
+  name = "test_role"
+}
+
+resource "cockroachdb_role_setting" "test_role_setting" {
+  role     = cockroachdb_role.test_role.name
+  database = "defaultdb"
+  variable = "statement_timeout"
+  value    = "20s"
+}
+
+resource "cockroachdb_role_setting" "test_all_setting" {
+  role     = "ALL"
+  variable = "application_name"
+  value    = "terraform"
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cockroachdb_role_setting.test_role_setting", "value", "20s"),
+					resource.TestCheckResourceAttr("cockroachdb_role_setting.test_all_setting", "role", "ALL"),
+					resource.TestCheckNoResourceAttr("cockroachdb_role_setting.test_all_setting", "database"),
+					resource.TestCheckResourceAttr("cockroachdb_role_setting.test_all_setting", "value", "terraform"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
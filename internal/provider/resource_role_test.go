@@ -1,9 +1,12 @@
 package provider
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccRoleResource(t *testing.T) {
@@ -34,6 +37,25 @@ resource "cockroachdb_role" "complex_role" {
 					resource.TestCheckResourceAttr("cockroachdb_role.complex_role", "login", "true"),
 				),
 			},
+			// Out of band password change testing
+			{
+				PreConfig: func() { alterRolePassword(t, "complex", "changed_out_of_band") },
+				Config: prefixProvider(`
+resource "cockroachdb_role" "simple_role" {
+  name = "simple"
+}
+
+resource "cockroachdb_role" "complex_role" {
+  name = "complex"
+  password = "test_password_abcdefg"
+  create_database = true
+  create_role = true
+  login = true
+}
+`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
 			// ImportState testing
 			{
 				ResourceName:            "cockroachdb_role.simple_role",
@@ -47,6 +69,41 @@ resource "cockroachdb_role" "complex_role" {
 				ImportStateVerify:       true,
 				ImportStateVerifyIgnore: []string{"id", "password"},
 			},
+			// Role options testing
+			{
+				Config: prefixProvider(`
+resource "cockroachdb_role" "simple_role" {
+  name = "simple"
+}
+
+resource "cockroachdb_role" "complex_role" {
+  name = "complex"
+  password = "test_password_abcdefg"
+  create_database = true
+  create_role = true
+  login = true
+  control_job = true
+  view_activity = true
+  cancel_query = true
+  view_cluster_setting = false
+  valid_until = "2099-01-01T00:00:00Z"
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cockroachdb_role.complex_role", "control_job", "true"),
+					resource.TestCheckResourceAttr("cockroachdb_role.complex_role", "view_activity", "true"),
+					resource.TestCheckResourceAttr("cockroachdb_role.complex_role", "cancel_query", "true"),
+					resource.TestCheckResourceAttr("cockroachdb_role.complex_role", "view_cluster_setting", "false"),
+					resource.TestCheckResourceAttr("cockroachdb_role.complex_role", "valid_until", "2099-01-01T00:00:00Z"),
+					resource.TestCheckNoResourceAttr("cockroachdb_role.simple_role", "control_job"),
+				),
+			},
+			{
+				ResourceName:            "cockroachdb_role.complex_role",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"id", "password"},
+			},
 			// Update and Read testing
 			{
 				Config: prefixProvider(`
@@ -61,6 +118,9 @@ resource "cockroachdb_role" "complex_role" {
 					resource.TestCheckNoResourceAttr("cockroachdb_role.complex_role", "create_database"),
 					resource.TestCheckNoResourceAttr("cockroachdb_role.complex_role", "create_role"),
 					resource.TestCheckNoResourceAttr("cockroachdb_role.complex_role", "login"),
+					resource.TestCheckNoResourceAttr("cockroachdb_role.complex_role", "control_job"),
+					resource.TestCheckNoResourceAttr("cockroachdb_role.complex_role", "view_activity"),
+					resource.TestCheckNoResourceAttr("cockroachdb_role.complex_role", "valid_until"),
 				),
 			},
 			{
@@ -73,3 +133,261 @@ resource "cockroachdb_role" "complex_role" {
 		},
 	})
 }
+
+func TestAccRoleResourceGeneratedPassword(t *testing.T) {
+	var firstPassword string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: prefixProvider(`
+resource "cockroachdb_role" "generated_role" {
+  name  = "generated"
+  login = true
+
+  generate_password {
+    length  = 40
+    special = true
+  }
+
+  rotation_triggers = {
+    version = "1"
+  }
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("cockroachdb_role.generated_role", "password_rotated_at"),
+					resource.TestCheckResourceAttrWith("cockroachdb_role.generated_role", "password", func(value string) error {
+						if len(value) != 40 {
+							return fmt.Errorf("expected a 40 character password, got %d characters", len(value))
+						}
+						firstPassword = value
+						return nil
+					}),
+				),
+			},
+			// Rotation testing
+			{
+				Config: prefixProvider(`
+resource "cockroachdb_role" "generated_role" {
+  name  = "generated"
+  login = true
+
+  generate_password {
+    length  = 40
+    special = true
+  }
+
+  rotation_triggers = {
+    version = "2"
+  }
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("cockroachdb_role.generated_role", "password", func(value string) error {
+						if value == firstPassword {
+							return fmt.Errorf("expected the password to be rotated")
+						}
+						return nil
+					}),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccRoleResourceWriteOnlyPassword(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: prefixProvider(`
+resource "cockroachdb_role" "write_only_role" {
+  name                = "write_only"
+  login               = true
+  password_wo         = "first_password"
+  password_wo_version = 1
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cockroachdb_role.write_only_role", "password_wo_version", "1"),
+					resource.TestCheckNoResourceAttr("cockroachdb_role.write_only_role", "password_wo"),
+					resource.TestCheckNoResourceAttr("cockroachdb_role.write_only_role", "password"),
+				),
+			},
+			// Out of band password change testing
+			{
+				PreConfig: func() { alterRolePassword(t, "write_only", "changed_out_of_band") },
+				Config: prefixProvider(`
+resource "cockroachdb_role" "write_only_role" {
+  name                = "write_only"
+  login               = true
+  password_wo         = "first_password"
+  password_wo_version = 1
+}
+`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Update testing
+			{
+				Config: prefixProvider(`
+resource "cockroachdb_role" "write_only_role" {
+  name                = "write_only"
+  login               = true
+  password_wo         = "second_password"
+  password_wo_version = 2
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cockroachdb_role.write_only_role", "password_wo_version", "2"),
+					resource.TestCheckNoResourceAttr("cockroachdb_role.write_only_role", "password_wo"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccRoleResourceMemberOf(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: prefixProvider(`
+resource "cockroachdb_role" "parent_a" {
+  name = "parent_a"
+}
+
+resource "cockroachdb_role" "parent_b" {
+  name = "parent_b"
+}
+
+resource "cockroachdb_role" "member_role" {
+  name      = "member"
+  member_of = [cockroachdb_role.parent_a.name]
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cockroachdb_role.member_role", "member_of.#", "1"),
+					resource.TestCheckTypeSetElemAttr("cockroachdb_role.member_role", "member_of.*", "parent_a"),
+				),
+			},
+			// Update testing
+			{
+				Config: prefixProvider(`
+resource "cockroachdb_role" "parent_a" {
+  name = "parent_a"
+}
+
+resource "cockroachdb_role" "parent_b" {
+  name = "parent_b"
+}
+
+resource "cockroachdb_role" "member_role" {
+  name      = "member"
+  member_of = [cockroachdb_role.parent_b.name]
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cockroachdb_role.member_role", "member_of.#", "1"),
+					resource.TestCheckTypeSetElemAttr("cockroachdb_role.member_role", "member_of.*", "parent_b"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccRoleResourceReassignOwned(t *testing.T) {
+	config := prefixProvider(`
+resource "cockroachdb_role" "owner_role" {
+  name              = "owner"
+  reassign_owned_to = "root"
+  drop_owned        = true
+}
+`)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: providerFactories,
+		CheckDestroy:             checkOwnedTableReassigned,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cockroachdb_role.owner_role", "reassign_owned_to", "root"),
+					resource.TestCheckResourceAttr("cockroachdb_role.owner_role", "drop_owned", "true"),
+				),
+			},
+			// The role owns a table, which must not block deleting it
+			{
+				PreConfig: func() { createOwnedTable(t, "owner") },
+				Config:    config,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func createOwnedTable(t *testing.T, role string) {
+	conn, err := getDbConn()
+	if err != nil {
+		t.Error(
+			"Cockroach database connection error",
+			err.Error(),
+		)
+		return
+	}
+
+	_, err = conn.Exec(context.Background(), fmt.Sprintf(`CREATE TABLE owned_tractor (tractor_id INTEGER UNIQUE); ALTER TABLE owned_tractor OWNER TO "%s"`, role))
+	if err != nil {
+		t.Error(
+			"Cockroach error creating owned table",
+			err.Error(),
+		)
+	}
+}
+
+func checkOwnedTableReassigned(s *terraform.State) error {
+	conn, err := getDbConn()
+	if err != nil {
+		return err
+	}
+
+	var owner string
+	err = conn.QueryRow(context.Background(), `SELECT tableowner FROM pg_catalog.pg_tables WHERE tablename = 'owned_tractor'`).Scan(&owner)
+	if err != nil {
+		return err
+	}
+	if owner != "root" {
+		return fmt.Errorf("expected owned_tractor to be reassigned to root, owned by %s", owner)
+	}
+
+	_, err = conn.Exec(context.Background(), `DROP TABLE owned_tractor`)
+	return err
+}
+
+func alterRolePassword(t *testing.T, role string, password string) {
+	conn, err := getDbConn()
+	if err != nil {
+		t.Error(
+			"Cockroach database connection error",
+			err.Error(),
+		)
+		return
+	}
+
+	_, err = conn.Exec(context.Background(), fmt.Sprintf(`ALTER ROLE "%s" WITH PASSWORD '%s'`, role, password))
+	if err != nil {
+		t.Error(
+			"Cockroach error changing role password",
+			err.Error(),
+		)
+	}
+}
@@ -0,0 +1,404 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"telusag/terraform-provider-cockroachdb/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jackc/pgx/v5"
+	"github.com/lib/pq"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &resourceSystemPrivileges{}
+	_ resource.ResourceWithConfigure      = &resourceSystemPrivileges{}
+	_ resource.ResourceWithImportState    = &resourceSystemPrivileges{}
+	_ resource.ResourceWithValidateConfig = &resourceSystemPrivileges{}
+)
+
+func NewSystemPrivilegesResource() resource.Resource {
+	return &resourceSystemPrivileges{}
+}
+
+type resourceSystemPrivileges struct {
+	p *cockroachdbProvider
+}
+
+func (r *resourceSystemPrivileges) Metadata(_ context.Context, _ resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = "cockroachdb_system_privileges"
+}
+
+func (r *resourceSystemPrivileges) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the complete set of system privileges (`GRANT SYSTEM`) of a role. Requires CockroachDB 23.1 or later.",
+		Attributes: map[string]schema.Attribute{
+			"role": schema.StringAttribute{
+				Description: "Target role name",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"privileges": schema.SetAttribute{
+				Description: "System privileges of the role, e.g. `VIEWACTIVITY` or `MODIFYCLUSTERSETTING`. Privileges not listed are revoked. Names are compared case-insensitively.",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"with_grant_option": schema.BoolAttribute{
+				Description: "Allows the role to grant the privileges to other roles. Default value is false.",
+				Optional:    true,
+			},
+			"id": schema.StringAttribute{
+				Description: "ID of the system privileges, the role name",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *resourceSystemPrivileges) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.p = req.ProviderData.(*cockroachdbProvider)
+}
+
+// ValidateConfig checks that every privilege is a system privilege
+func (r *resourceSystemPrivileges) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config SystemPrivileges
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.Privileges.IsUnknown() {
+		return
+	}
+
+	privileges := []string{}
+	config.Privileges.ElementsAs(ctx, &privileges, false)
+	if err := utils.ValidatePrivileges(ctx, "system", privileges); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("privileges"),
+			"Invalid system privilege",
+			err.Error(),
+		)
+	}
+}
+
+func readSystemPrivileges(ctx context.Context, conn dbExecutor, systemPrivileges *SystemPrivileges) error {
+	var (
+		privilegeType string
+		isGrantable   bool
+	)
+
+	privileges := []string{}
+	grantable := true
+
+	rows, err := conn.Query(ctx, fmt.Sprintf(
+		"SELECT privilege_type, is_grantable FROM [SHOW SYSTEM GRANTS FOR %s]",
+		pq.QuoteIdentifier(systemPrivileges.Role.ValueString()),
+	))
+	if err != nil {
+		return err
+	}
+
+	_, err = pgx.ForEachRow(rows, []any{&privilegeType, &isGrantable}, func() error {
+		privileges = append(privileges, privilegeType)
+		// The grant option only holds if every privilege can be granted on
+		grantable = grantable && isGrantable
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Set privileges, spelled the way they already are
+	currentPrivileges := []string{}
+	systemPrivileges.Privileges.ElementsAs(ctx, &currentPrivileges, false)
+	systemPrivileges.Privileges, _ = types.SetValueFrom(ctx, types.StringType, normalizePrivileges("system", privileges, currentPrivileges))
+
+	// Only report the grant option if it's set or being managed
+	grantable = grantable && len(privileges) > 0
+	if grantable || !systemPrivileges.WithGrantOption.IsNull() {
+		systemPrivileges.WithGrantOption = types.BoolValue(grantable)
+	}
+
+	systemPrivileges.ID = systemPrivileges.Role
+
+	return nil
+}
+
+// setSystemPrivileges replaces every system privilege of the role with the
+// ones in systemPrivileges
+func setSystemPrivileges(ctx context.Context, conn dbExecutor, systemPrivileges *SystemPrivileges) error {
+	privileges := []string{}
+	systemPrivileges.Privileges.ElementsAs(ctx, &privileges, false)
+	role := pq.QuoteIdentifier(systemPrivileges.Role.ValueString())
+
+	queries := []string{
+		fmt.Sprintf("REVOKE SYSTEM ALL FROM %s", role),
+		fmt.Sprintf("GRANT SYSTEM %s TO %s", strings.Join(privileges, ","), role),
+	}
+	if systemPrivileges.WithGrantOption.ValueBool() {
+		queries[1] += " WITH GRANT OPTION"
+	}
+
+	for _, query := range queries {
+		tflog.Info(ctx, query)
+
+		if _, err := conn.Exec(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	systemPrivileges.ID = systemPrivileges.Role
+
+	return nil
+}
+
+func revokeSystemPrivileges(ctx context.Context, conn dbExecutor, systemPrivileges SystemPrivileges) error {
+	query := fmt.Sprintf("REVOKE SYSTEM ALL FROM %s", pq.QuoteIdentifier(systemPrivileges.Role.ValueString()))
+
+	tflog.Info(ctx, query)
+
+	_, err := conn.Exec(ctx, query)
+	return err
+}
+
+// Create a new resource
+func (r *resourceSystemPrivileges) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan SystemPrivileges
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Connect to db
+	conn, err := r.p.Conn(ctx, "")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach connection error",
+			err.Error(),
+		)
+		return
+	}
+	defer conn.Close(ctx)
+
+	// Replace whatever the role already has so it ends up with exactly the planned set
+	err = executeInTx(ctx, conn, func(tx pgx.Tx) error {
+		return setSystemPrivileges(ctx, tx, &plan)
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach sql error",
+			err.Error(),
+		)
+		return
+	}
+
+	// Read back what was just set in DB
+	err = readSystemPrivileges(ctx, conn, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach sql error",
+			err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information
+func (r *resourceSystemPrivileges) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state SystemPrivileges
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// When importing, the ID is the role name
+	if state.Role.IsNull() {
+		state.Role = state.ID
+	}
+
+	// Connect to db
+	conn, err := r.p.Conn(ctx, "")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach connection error",
+			err.Error(),
+		)
+		return
+	}
+	defer conn.Close(ctx)
+
+	exists, err := roleExists(ctx, conn, state.Role.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach sql error",
+			err.Error(),
+		)
+		return
+	}
+
+	// The role was dropped outside of terraform, taking its privileges with it
+	if !exists {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	err = readSystemPrivileges(ctx, conn, &state)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach sql error",
+			err.Error(),
+		)
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update resource
+func (r *resourceSystemPrivileges) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var (
+		state SystemPrivileges
+		plan  SystemPrivileges
+	)
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Connect to db
+	conn, err := r.p.Conn(ctx, "")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach connection error",
+			err.Error(),
+		)
+		return
+	}
+	defer conn.Close(ctx)
+
+	// A renamed role keeps its privileges, so revoke them under its new name
+	state.Role, err = repointRenamedRole(ctx, conn, state.Role, plan.Role)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach sql error",
+			err.Error(),
+		)
+		return
+	}
+
+	err = executeInTx(ctx, conn, func(tx pgx.Tx) error {
+		if state.Role.ValueString() != plan.Role.ValueString() {
+			if err := revokeSystemPrivileges(ctx, tx, state); err != nil {
+				return err
+			}
+		}
+
+		return setSystemPrivileges(ctx, tx, &plan)
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach sql error",
+			err.Error(),
+		)
+		return
+	}
+
+	// Read back what was set in DB
+	err = readSystemPrivileges(ctx, conn, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach sql error",
+			err.Error(),
+		)
+		return
+	}
+
+	// Update state with what was actually stored
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete resource
+func (r *resourceSystemPrivileges) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state SystemPrivileges
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Connect to db
+	conn, err := r.p.Conn(ctx, "")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach connection error",
+			err.Error(),
+		)
+		return
+	}
+	defer conn.Close(ctx)
+
+	err = revokeSystemPrivileges(ctx, conn, state)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach sql error",
+			err.Error(),
+		)
+		return
+	}
+}
+
+func (r *resourceSystemPrivileges) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+type SystemPrivileges struct {
+	ID              types.String `tfsdk:"id"`
+	Role            types.String `tfsdk:"role"`
+	Privileges      types.Set    `tfsdk:"privileges"`
+	WithGrantOption types.Bool   `tfsdk:"with_grant_option"`
+}
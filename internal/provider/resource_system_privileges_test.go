@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSystemPrivilegesResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: prefixProvider(`
+resource "cockroachdb_role" "test_role" {
+	name = "test_role"
+}
+
+resource "cockroachdb_system_privileges" "test_system_privileges" {
+	role       = cockroachdb_role.test_role.name
+	privileges = ["VIEWACTIVITY", "viewclustersetting"]
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cockroachdb_system_privileges.test_system_privileges", "id", "test_role"),
+					resource.TestCheckTypeSetElemAttr("cockroachdb_system_privileges.test_system_privileges", "privileges.*", "VIEWACTIVITY"),
+					resource.TestCheckTypeSetElemAttr("cockroachdb_system_privileges.test_system_privileges", "privileges.*", "viewclustersetting"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "cockroachdb_system_privileges.test_system_privileges",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Import reads the server's spelling
+				ImportStateVerifyIgnore: []string{"privileges"},
+			},
+			// Update and Read testing
+			{
+				Config: prefixProvider(`
+resource "cockroachdb_role" "test_role" {
+	name = "test_role"
+}
+
+resource "cockroachdb_system_privileges" "test_system_privileges" {
+	role              = cockroachdb_role.test_role.name
+	privileges        = ["VIEWACTIVITY"]
+	with_grant_option = true
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cockroachdb_system_privileges.test_system_privileges", "privileges.#", "1"),
+					resource.TestCheckResourceAttr("cockroachdb_system_privileges.test_system_privileges", "with_grant_option", "true"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
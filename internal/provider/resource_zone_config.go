@@ -0,0 +1,708 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jackc/pgx/v5"
+	"github.com/lib/pq"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &resourceZoneConfig{}
+	_ resource.ResourceWithConfigure        = &resourceZoneConfig{}
+	_ resource.ResourceWithConfigValidators = &resourceZoneConfig{}
+	_ resource.ResourceWithImportState      = &resourceZoneConfig{}
+)
+
+func NewZoneConfigResource() resource.Resource {
+	return &resourceZoneConfig{}
+}
+
+type resourceZoneConfig struct {
+	p *cockroachdbProvider
+}
+
+func (r *resourceZoneConfig) Metadata(_ context.Context, _ resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = "cockroachdb_zone_config"
+}
+
+func (r *resourceZoneConfig) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	replaceString := []planmodifier.String{
+		stringplanmodifier.RequiresReplace(),
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Manages the zone configuration of a database, table, index, partition or named range.",
+		Attributes: map[string]schema.Attribute{
+			"range": schema.StringAttribute{
+				Description: "Named range to configure. Must be one of the following: default, meta, liveness, system, timeseries",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("default", "meta", "liveness", "system", "timeseries"),
+				},
+				PlanModifiers: replaceString,
+			},
+			"database": schema.StringAttribute{
+				Description: "Target database name. Configures the database itself unless `table` is also set.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: replaceString,
+			},
+			"schema": schema.StringAttribute{
+				Description: "Schema of the target table. Defaults to public.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.AlsoRequires(path.MatchRoot("table")),
+				},
+				PlanModifiers: replaceString,
+			},
+			"table": schema.StringAttribute{
+				Description: "Target table name. Configures the table itself unless `index` or `partition` is also set.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.AlsoRequires(path.MatchRoot("database")),
+				},
+				PlanModifiers: replaceString,
+			},
+			"index": schema.StringAttribute{
+				Description: "Target index name on `table`",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.AlsoRequires(path.MatchRoot("table")),
+				},
+				PlanModifiers: replaceString,
+			},
+			"partition": schema.StringAttribute{
+				Description: "Target partition name of `table`, or of `index` when it is set",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.AlsoRequires(path.MatchRoot("table")),
+				},
+				PlanModifiers: replaceString,
+			},
+			"num_replicas": schema.Int64Attribute{
+				Description: "Number of replicas for ranges in the zone",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"num_voters": schema.Int64Attribute{
+				Description: "Number of voting replicas for ranges in the zone",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"gc_ttlseconds": schema.Int64Attribute{
+				Description: "Number of seconds overwritten values are retained before garbage collection",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"range_min_bytes": schema.Int64Attribute{
+				Description: "Minimum size, in bytes, for a range of data in the zone",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"range_max_bytes": schema.Int64Attribute{
+				Description: "Maximum size, in bytes, for a range of data in the zone",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"constraints": schema.ListAttribute{
+				Description: "Required (+) and prohibited (-) constraints applied to every replica, e.g. `+region=us-east1`",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"voter_constraints": schema.ListAttribute{
+				Description: "Required (+) and prohibited (-) constraints applied to every voting replica",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"lease_preferences": schema.ListAttribute{
+				Description: "Ordered list of lease preferences, each a list of constraints",
+				Optional:    true,
+				ElementType: types.ListType{ElemType: types.StringType},
+			},
+			"id": schema.StringAttribute{
+				Description: "ID of the zone configuration (the zone target, e.g. `TABLE app.public.users`)",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *resourceZoneConfig) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("range"),
+			path.MatchRoot("database"),
+		),
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("num_replicas"),
+			path.MatchRoot("num_voters"),
+			path.MatchRoot("gc_ttlseconds"),
+			path.MatchRoot("range_min_bytes"),
+			path.MatchRoot("range_max_bytes"),
+			path.MatchRoot("constraints"),
+			path.MatchRoot("voter_constraints"),
+			path.MatchRoot("lease_preferences"),
+		),
+	}
+}
+
+func (r *resourceZoneConfig) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.p = req.ProviderData.(*cockroachdbProvider)
+}
+
+// getZoneTarget returns the zone target in the form CockroachDB reports it
+// (e.g. `INDEX app.public.users@users_email_idx`) and the quoted form used in
+// `ALTER ... CONFIGURE ZONE` statements.
+func getZoneTarget(zone ZoneConfig) (string, string) {
+	if zone.Range.ValueString() != "" {
+		return "RANGE " + zone.Range.ValueString(), "RANGE " + pq.QuoteIdentifier(zone.Range.ValueString())
+	}
+
+	if zone.Table.ValueString() == "" {
+		return "DATABASE " + zone.Database.ValueString(), "DATABASE " + pq.QuoteIdentifier(zone.Database.ValueString())
+	}
+
+	schemaName := zone.Schema.ValueString()
+	if schemaName == "" {
+		schemaName = "public"
+	}
+	table := strings.Join([]string{zone.Database.ValueString(), schemaName, zone.Table.ValueString()}, ".")
+	quotedTable := strings.Join([]string{
+		pq.QuoteIdentifier(zone.Database.ValueString()),
+		pq.QuoteIdentifier(schemaName),
+		pq.QuoteIdentifier(zone.Table.ValueString()),
+	}, ".")
+
+	target, quotedTarget := "TABLE "+table, "TABLE "+quotedTable
+	if zone.Index.ValueString() != "" {
+		target = "INDEX " + table + "@" + zone.Index.ValueString()
+		quotedTarget = "INDEX " + quotedTable + "@" + pq.QuoteIdentifier(zone.Index.ValueString())
+	}
+
+	if zone.Partition.ValueString() != "" {
+		target = "PARTITION " + zone.Partition.ValueString() + " OF " + target
+		quotedTarget = "PARTITION " + pq.QuoteIdentifier(zone.Partition.ValueString()) + " OF " + quotedTarget
+	}
+
+	return target, quotedTarget
+}
+
+var zoneTargetRegexp = regexp.MustCompile(`^(?:PARTITION (\S+) OF )?(RANGE|DATABASE|TABLE|INDEX) ([^.@\s]+)(?:\.([^.@\s]+)\.([^.@\s]+))?(?:@(\S+))?$`)
+
+// parseZoneTarget is the inverse of getZoneTarget and is used when importing
+func parseZoneTarget(id string, zone *ZoneConfig) error {
+	matches := zoneTargetRegexp.FindStringSubmatch(id)
+	if matches == nil {
+		return fmt.Errorf("unable to parse zone target %q", id)
+	}
+
+	setIfPresent := func(value string) types.String {
+		if value == "" {
+			return types.StringNull()
+		}
+		return types.StringValue(value)
+	}
+
+	if matches[2] == "RANGE" {
+		zone.Range = types.StringValue(matches[3])
+		return nil
+	}
+
+	zone.Database = types.StringValue(matches[3])
+	zone.Schema = setIfPresent(matches[4])
+	zone.Table = setIfPresent(matches[5])
+	zone.Index = setIfPresent(matches[6])
+	zone.Partition = setIfPresent(matches[1])
+
+	return nil
+}
+
+// isPartitionOfPrimaryIndex reports whether a zone for a partition of a table
+// was reported against the table's primary index, which is how CockroachDB
+// names partitions when no index is given.
+func isPartitionOfPrimaryIndex(zone ZoneConfig, reportedTarget string) bool {
+	if zone.Partition.ValueString() == "" || zone.Index.ValueString() != "" {
+		return false
+	}
+
+	schemaName := zone.Schema.ValueString()
+	if schemaName == "" {
+		schemaName = "public"
+	}
+
+	return strings.HasPrefix(reportedTarget, fmt.Sprintf(
+		"PARTITION %s OF INDEX %s.%s.%s@",
+		zone.Partition.ValueString(),
+		zone.Database.ValueString(),
+		schemaName,
+		zone.Table.ValueString(),
+	))
+}
+
+func formatConstraints(constraints []string) string {
+	return pq.QuoteLiteral("[" + strings.Join(constraints, ", ") + "]")
+}
+
+var leasePreferenceRegexp = regexp.MustCompile(`\[([^\[\]]*)\]`)
+
+func parseConstraints(raw string) []string {
+	constraints := []string{}
+	for _, constraint := range strings.Split(strings.Trim(raw, "[]"), ",") {
+		if constraint = strings.TrimSpace(constraint); constraint != "" {
+			constraints = append(constraints, constraint)
+		}
+	}
+	return constraints
+}
+
+// getZoneConfigSettings returns the `CONFIGURE ZONE USING` assignments for
+// the plan. Settings that are set in state but no longer in the plan are
+// reset to the value inherited from the parent zone.
+func getZoneConfigSettings(ctx context.Context, plan ZoneConfig, state *ZoneConfig) []string {
+	settings := []string{}
+
+	addInt := func(name string, planVal types.Int64, stateVal types.Int64) {
+		if !planVal.IsNull() {
+			settings = append(settings, fmt.Sprintf("%s = %d", name, planVal.ValueInt64()))
+		} else if state != nil && !stateVal.IsNull() {
+			settings = append(settings, fmt.Sprintf("%s = COPY FROM PARENT", name))
+		}
+	}
+
+	addConstraints := func(name string, planVal types.List, stateVal types.List) {
+		if !planVal.IsNull() {
+			constraints := []string{}
+			planVal.ElementsAs(ctx, &constraints, false)
+			settings = append(settings, fmt.Sprintf("%s = %s", name, formatConstraints(constraints)))
+		} else if state != nil && !stateVal.IsNull() {
+			settings = append(settings, fmt.Sprintf("%s = COPY FROM PARENT", name))
+		}
+	}
+
+	var (
+		stateReplicas, stateVoters, stateTTL, stateMin, stateMax types.Int64
+		stateConstraints, stateVoterConstraints, stateLease      types.List
+	)
+	if state != nil {
+		stateReplicas, stateVoters, stateTTL = state.NumReplicas, state.NumVoters, state.GcTtlSeconds
+		stateMin, stateMax = state.RangeMinBytes, state.RangeMaxBytes
+		stateConstraints, stateVoterConstraints, stateLease = state.Constraints, state.VoterConstraints, state.LeasePreferences
+	}
+
+	addInt("num_replicas", plan.NumReplicas, stateReplicas)
+	addInt("num_voters", plan.NumVoters, stateVoters)
+	addInt("gc.ttlseconds", plan.GcTtlSeconds, stateTTL)
+	addInt("range_min_bytes", plan.RangeMinBytes, stateMin)
+	addInt("range_max_bytes", plan.RangeMaxBytes, stateMax)
+	addConstraints("constraints", plan.Constraints, stateConstraints)
+	addConstraints("voter_constraints", plan.VoterConstraints, stateVoterConstraints)
+
+	if !plan.LeasePreferences.IsNull() {
+		preferences := [][]string{}
+		plan.LeasePreferences.ElementsAs(ctx, &preferences, false)
+
+		formatted := []string{}
+		for _, preference := range preferences {
+			formatted = append(formatted, "["+strings.Join(preference, ", ")+"]")
+		}
+		settings = append(settings, fmt.Sprintf("lease_preferences = %s", pq.QuoteLiteral("["+strings.Join(formatted, ", ")+"]")))
+	} else if state != nil && !stateLease.IsNull() {
+		settings = append(settings, "lease_preferences = COPY FROM PARENT")
+	}
+
+	return settings
+}
+
+func configureZone(ctx context.Context, conn *pgx.Conn, plan *ZoneConfig, state *ZoneConfig) error {
+	target, quotedTarget := getZoneTarget(*plan)
+
+	settings := getZoneConfigSettings(ctx, *plan, state)
+	if len(settings) > 0 {
+		query := fmt.Sprintf("ALTER %s CONFIGURE ZONE USING %s", quotedTarget, strings.Join(settings, ", "))
+
+		tflog.Info(ctx, query)
+
+		if _, err := conn.Exec(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	plan.ID = types.StringValue(target)
+
+	return nil
+}
+
+// parseZoneConfigSQL turns the `raw_config_sql` column returned by
+// `SHOW ZONE CONFIGURATION` into a map of setting name to raw value. Each
+// setting is on its own line, e.g. `	num_replicas = 3,`.
+func parseZoneConfigSQL(raw string) map[string]string {
+	settings := map[string]string{}
+
+	idx := strings.Index(raw, "USING")
+	if idx < 0 {
+		return settings
+	}
+
+	for _, line := range strings.Split(raw[idx+len("USING"):], "\n") {
+		line = strings.TrimSuffix(strings.TrimSpace(line), ",")
+		pieces := strings.SplitN(line, " = ", 2)
+		if len(pieces) != 2 {
+			continue
+		}
+		settings[strings.TrimSpace(pieces[0])] = strings.Trim(strings.TrimSpace(pieces[1]), "'")
+	}
+
+	return settings
+}
+
+// readZoneConfig refreshes the settings managed by terraform, or every
+// setting of the zone when importing. Settings that are not set on the
+// resource are inherited and therefore left untouched. It returns false when
+// the zone no longer has its own configuration.
+func readZoneConfig(ctx context.Context, conn *pgx.Conn, zone *ZoneConfig, importing bool) (bool, error) {
+	target, quotedTarget := getZoneTarget(*zone)
+
+	var (
+		reportedTarget string
+		rawConfigSQL   string
+	)
+	query := fmt.Sprintf("SELECT target, raw_config_sql FROM [SHOW ZONE CONFIGURATION FOR %s]", quotedTarget)
+	tflog.Info(ctx, query)
+	err := conn.QueryRow(ctx, query).Scan(&reportedTarget, &rawConfigSQL)
+	if err != nil {
+		return false, err
+	}
+
+	// A zone without its own configuration reports the parent it inherits from
+	unquotedTarget := strings.ReplaceAll(reportedTarget, `"`, "")
+	if unquotedTarget != target && !isPartitionOfPrimaryIndex(*zone, unquotedTarget) {
+		return false, nil
+	}
+
+	// SHOW ZONE CONFIGURATION fills in inherited values, only the zone's raw
+	// configuration tells which settings it sets itself
+	var ownConfigSQL *string
+	query = "SELECT raw_config_sql FROM crdb_internal.zones WHERE target = $1"
+	tflog.Info(ctx, query)
+	err = conn.QueryRow(ctx, query, reportedTarget).Scan(&ownConfigSQL)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return false, err
+	}
+
+	settings := parseZoneConfigSQL(rawConfigSQL)
+	explicit := ownConfigSQL != nil
+	if explicit {
+		settings = parseZoneConfigSQL(*ownConfigSQL)
+	}
+
+	// lookup returns the reported value of a setting that is being refreshed.
+	// Settings removed outside of terraform are reset to null.
+	lookup := func(name string, isNull bool, setNull func()) (string, bool) {
+		if isNull && !importing {
+			return "", false
+		}
+		raw, ok := settings[name]
+		if !ok && explicit {
+			setNull()
+		}
+		return raw, ok
+	}
+
+	readInt := func(name string, val *types.Int64) {
+		raw, ok := lookup(name, val.IsNull(), func() { *val = types.Int64Null() })
+		if !ok {
+			return
+		}
+		if parsed, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			*val = types.Int64Value(parsed)
+		}
+	}
+
+	readConstraints := func(name string, val *types.List) {
+		raw, ok := lookup(name, val.IsNull(), func() { *val = types.ListNull(types.StringType) })
+		// Per-replica constraints are reported as a map, which is not supported
+		if !ok || strings.HasPrefix(raw, "{") {
+			return
+		}
+
+		elems := []attr.Value{}
+		for _, constraint := range parseConstraints(raw) {
+			elems = append(elems, types.StringValue(constraint))
+		}
+		*val, _ = types.ListValue(types.StringType, elems)
+	}
+
+	readInt("num_replicas", &zone.NumReplicas)
+	readInt("num_voters", &zone.NumVoters)
+	readInt("gc.ttlseconds", &zone.GcTtlSeconds)
+	readInt("range_min_bytes", &zone.RangeMinBytes)
+	readInt("range_max_bytes", &zone.RangeMaxBytes)
+	readConstraints("constraints", &zone.Constraints)
+	readConstraints("voter_constraints", &zone.VoterConstraints)
+
+	leaseType := types.ListType{ElemType: types.StringType}
+	if raw, ok := lookup("lease_preferences", zone.LeasePreferences.IsNull(), func() { zone.LeasePreferences = types.ListNull(leaseType) }); ok {
+		inner := strings.TrimSpace(raw)
+		inner = strings.TrimSuffix(strings.TrimPrefix(inner, "["), "]")
+
+		preferences := []attr.Value{}
+		for _, match := range leasePreferenceRegexp.FindAllStringSubmatch(inner, -1) {
+			elems := []attr.Value{}
+			for _, constraint := range parseConstraints(match[1]) {
+				elems = append(elems, types.StringValue(constraint))
+			}
+			preference, _ := types.ListValue(types.StringType, elems)
+			preferences = append(preferences, preference)
+		}
+		zone.LeasePreferences, _ = types.ListValue(leaseType, preferences)
+	}
+
+	return true, nil
+}
+
+// Create a new resource
+func (r *resourceZoneConfig) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ZoneConfig
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Connect to db
+	conn, err := r.p.Conn(ctx, "")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach connection error",
+			err.Error(),
+		)
+		return
+	}
+	defer conn.Close(ctx)
+
+	err = configureZone(ctx, conn, &plan, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach execute sql error",
+			err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information
+func (r *resourceZoneConfig) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ZoneConfig
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// In cases where we are importing state from a single ID, parse the ID into the proper pieces
+	importing := state.Range.IsNull() && state.Database.IsNull()
+	if importing {
+		if err := parseZoneTarget(state.ID.ValueString(), &state); err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid zone configuration ID",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	// Connect to db
+	conn, err := r.p.Conn(ctx, "")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach connection error",
+			err.Error(),
+		)
+		return
+	}
+	defer conn.Close(ctx)
+
+	found, err := readZoneConfig(ctx, conn, &state, importing)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach execute sql error",
+			err.Error(),
+		)
+		return
+	}
+
+	// The zone configuration was discarded outside of terraform
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update resource
+func (r *resourceZoneConfig) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var (
+		state ZoneConfig
+		plan  ZoneConfig
+	)
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Connect to db
+	conn, err := r.p.Conn(ctx, "")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach connection error",
+			err.Error(),
+		)
+		return
+	}
+	defer conn.Close(ctx)
+
+	err = configureZone(ctx, conn, &plan, &state)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach execute sql error",
+			err.Error(),
+		)
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete resource
+func (r *resourceZoneConfig) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ZoneConfig
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Connect to db
+	conn, err := r.p.Conn(ctx, "")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach connection error",
+			err.Error(),
+		)
+		return
+	}
+	defer conn.Close(ctx)
+
+	// The default range must always have a configuration, so it is left as is
+	if state.Range.ValueString() == "default" {
+		resp.Diagnostics.AddWarning(
+			"Zone configuration not discarded",
+			"The zone configuration of RANGE default cannot be discarded, it has been removed from state but left unchanged in the cluster.",
+		)
+		return
+	}
+
+	_, quotedTarget := getZoneTarget(state)
+	query := fmt.Sprintf("ALTER %s CONFIGURE ZONE DISCARD", quotedTarget)
+
+	tflog.Info(ctx, query)
+
+	_, err = conn.Exec(ctx, query)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cockroach execute sql error",
+			err.Error(),
+		)
+		return
+	}
+}
+
+func (r *resourceZoneConfig) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+type ZoneConfig struct {
+	ID               types.String `tfsdk:"id"`
+	Range            types.String `tfsdk:"range"`
+	Database         types.String `tfsdk:"database"`
+	Schema           types.String `tfsdk:"schema"`
+	Table            types.String `tfsdk:"table"`
+	Index            types.String `tfsdk:"index"`
+	Partition        types.String `tfsdk:"partition"`
+	NumReplicas      types.Int64  `tfsdk:"num_replicas"`
+	NumVoters        types.Int64  `tfsdk:"num_voters"`
+	GcTtlSeconds     types.Int64  `tfsdk:"gc_ttlseconds"`
+	RangeMinBytes    types.Int64  `tfsdk:"range_min_bytes"`
+	RangeMaxBytes    types.Int64  `tfsdk:"range_max_bytes"`
+	Constraints      types.List   `tfsdk:"constraints"`
+	VoterConstraints types.List   `tfsdk:"voter_constraints"`
+	LeasePreferences types.List   `tfsdk:"lease_preferences"`
+}
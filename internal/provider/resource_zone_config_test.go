@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccZoneConfigResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: providerFactories,
+		PreCheck:                 func() { createTractorTable(t) },
+		CheckDestroy:             destroyTractorTable,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: prefixProvider(`
+resource "cockroachdb_zone_config" "test_database_zone" {
+  database      = "defaultdb"
+  num_replicas  = 1
+  gc_ttlseconds = 3600
+}
+
+resource "cockroachdb_zone_config" "test_table_zone" {
+  database        = "defaultdb"
+  table           = "tractor"
+  range_max_bytes = 268435456
+  range_min_bytes = 67108864
+  constraints     = []
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cockroachdb_zone_config.test_database_zone", "id", "DATABASE defaultdb"),
+					resource.TestCheckResourceAttr("cockroachdb_zone_config.test_database_zone", "num_replicas", "1"),
+					resource.TestCheckResourceAttr("cockroachdb_zone_config.test_database_zone", "gc_ttlseconds", "3600"),
+					resource.TestCheckResourceAttr("cockroachdb_zone_config.test_table_zone", "id", "TABLE defaultdb.public.tractor"),
+					resource.TestCheckResourceAttr("cockroachdb_zone_config.test_table_zone", "range_max_bytes", "268435456"),
+					resource.TestCheckResourceAttr("cockroachdb_zone_config.test_table_zone", "constraints.#", "0"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "cockroachdb_zone_config.test_database_zone",
+				ImportState:       true,
+				ImportStateId:     "DATABASE defaultdb",
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "cockroachdb_zone_config.test_table_zone",
+				ImportState:       true,
+				ImportStateId:     "TABLE defaultdb.public.tractor",
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: prefixProvider(`
+resource "cockroachdb_zone_config" "test_database_zone" {
+  database     = "defaultdb"
+  num_replicas = 1
+}
+
+resource "cockroachdb_zone_config" "test_table_zone" {
+  database        = "defaultdb"
+  table           = "tractor"
+  range_max_bytes = 536870912
+  range_min_bytes = 67108864
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("cockroachdb_zone_config.test_database_zone", "gc_ttlseconds"),
+					resource.TestCheckResourceAttr("cockroachdb_zone_config.test_table_zone", "range_max_bytes", "536870912"),
+					resource.TestCheckNoResourceAttr("cockroachdb_zone_config.test_table_zone", "constraints"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
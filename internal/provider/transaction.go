@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// maxTxRetries caps the number of times a transaction is retried after a
+// serialization failure
+const maxTxRetries = 10
+
+// dbExecutor is satisfied by both *pgx.Conn and pgx.Tx so helpers can run
+// statements either on their own or as part of a transaction
+type dbExecutor interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// executeInTx runs fn inside one explicit transaction using CockroachDB's
+// client-side retry protocol: fn is re-run from the `cockroach_restart`
+// savepoint whenever the transaction fails with a retryable error (40001).
+//
+// Not every statement can run inside an explicit transaction. The following
+// are always executed on their own and must not be passed to executeInTx:
+//   - schema changes such as CREATE DATABASE or ALTER DATABASE, which
+//     CockroachDB advises against combining in an explicit transaction
+//   - BACKUP and waiting for its job, since the job of a backup only starts
+//     once the statement that created it commits
+//   - SET CLUSTER SETTING
+//   - statements that need a connection to a different database, e.g. grants
+//     when `database` changes
+func executeInTx(ctx context.Context, conn *pgx.Conn, fn func(tx pgx.Tx) error) error {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	// Rollback is a no-op once the transaction is committed
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, "SAVEPOINT cockroach_restart"); err != nil {
+		return err
+	}
+
+	for retry := 0; ; retry++ {
+		err = fn(tx)
+		if err == nil {
+			_, err = tx.Exec(ctx, "RELEASE SAVEPOINT cockroach_restart")
+			if err == nil {
+				return tx.Commit(ctx)
+			}
+		}
+
+		var pgErr *pgconn.PgError
+		if !errors.As(err, &pgErr) || pgErr.Code != "40001" {
+			return err
+		}
+		if retry >= maxTxRetries {
+			return fmt.Errorf("transaction retried %d times: %w", retry, err)
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("Retrying transaction after retryable error: %s", err.Error()))
+
+		if _, err := tx.Exec(ctx, "ROLLBACK TO SAVEPOINT cockroach_restart"); err != nil {
+			return err
+		}
+	}
+}
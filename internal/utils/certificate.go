@@ -0,0 +1,216 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"time"
+)
+
+// Key algorithms supported for client certificates
+const (
+	KeyAlgorithmRSA     = "RSA"
+	KeyAlgorithmECDSA   = "ECDSA"
+	KeyAlgorithmED25519 = "ED25519"
+)
+
+// certificateBackdate is how far the start of a certificate's validity is
+// moved into the past to allow for clock skew, like `cockroach cert` does
+const certificateBackdate = time.Hour
+
+// ClientCertificateRequest describes a CockroachDB client certificate
+type ClientCertificateRequest struct {
+	Username     string
+	KeyAlgorithm string
+	RSABits      int
+	Validity     time.Duration
+	// TenantScope lists the tenants the certificate is valid for
+	TenantScope []int64
+}
+
+// ClientCertificate is a signed client certificate and its private key
+type ClientCertificate struct {
+	CertPEM      string
+	KeyPEM       string
+	KeyPKCS8PEM  string
+	SerialNumber *big.Int
+	NotBefore    time.Time
+	NotAfter     time.Time
+}
+
+// ParseCertificatePEM returns the first certificate in a PEM bundle
+func ParseCertificatePEM(certPEM string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(certPEM))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("no PEM encoded certificate found")
+	}
+
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// ParsePrivateKeyPEM parses a PKCS #1, PKCS #8 or SEC 1 PEM encoded private key
+func ParsePrivateKeyPEM(keyPEM string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(keyPEM))
+	if block == nil {
+		return nil, errors.New("no PEM encoded private key found")
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported private key type %T", key)
+		}
+		return signer, nil
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+}
+
+func generateKey(algorithm string, rsaBits int) (crypto.Signer, error) {
+	switch algorithm {
+	case KeyAlgorithmRSA:
+		return rsa.GenerateKey(rand.Reader, rsaBits)
+	case KeyAlgorithmECDSA:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case KeyAlgorithmED25519:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		return key, err
+	default:
+		return nil, fmt.Errorf("unsupported key algorithm %q", algorithm)
+	}
+}
+
+// encodePrivateKeyPEM encodes RSA keys as PKCS #1 and ECDSA keys as SEC 1,
+// which is what `cockroach cert` writes. ED25519 keys can only be PKCS #8.
+func encodePrivateKeyPEM(key crypto.Signer) (string, error) {
+	var block *pem.Block
+
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		block = &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(k)}
+	case *ecdsa.PrivateKey:
+		der, err := x509.MarshalECPrivateKey(k)
+		if err != nil {
+			return "", err
+		}
+		block = &pem.Block{Type: "EC PRIVATE KEY", Bytes: der}
+	default:
+		return encodePKCS8PrivateKeyPEM(key)
+	}
+
+	return string(pem.EncodeToMemory(block)), nil
+}
+
+func encodePKCS8PrivateKeyPEM(key crypto.Signer) (string, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), nil
+}
+
+// IssueClientCertificate generates a key pair and signs a client certificate
+// for req.Username with the CA. The username is the certificate's common name
+// and, for every tenant in the scope, part of a `crdb://` URI SAN.
+func IssueClientCertificate(req ClientCertificateRequest, caCertPEM string, caKeyPEM string) (ClientCertificate, error) {
+	var cert ClientCertificate
+
+	caCert, err := ParseCertificatePEM(caCertPEM)
+	if err != nil {
+		return cert, fmt.Errorf("parsing CA certificate: %w", err)
+	}
+	if !caCert.IsCA {
+		return cert, errors.New("CA certificate is not a certificate authority")
+	}
+
+	caKey, err := ParsePrivateKeyPEM(caKeyPEM)
+	if err != nil {
+		return cert, fmt.Errorf("parsing CA private key: %w", err)
+	}
+
+	key, err := generateKey(req.KeyAlgorithm, req.RSABits)
+	if err != nil {
+		return cert, err
+	}
+
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return cert, err
+	}
+
+	notBefore := time.Now().Add(-certificateBackdate).UTC().Truncate(time.Second)
+	notAfter := notBefore.Add(certificateBackdate + req.Validity)
+	// A certificate can't outlive the CA that signed it
+	if notAfter.After(caCert.NotAfter) {
+		notAfter = caCert.NotAfter
+	}
+
+	uris := []*url.URL{}
+	for _, tenant := range req.TenantScope {
+		uris = append(uris, &url.URL{
+			Scheme: "crdb",
+			Host:   "tenant",
+			Path:   fmt.Sprintf("/%d/user/%s", tenant, req.Username),
+		})
+	}
+
+	keyUsage := x509.KeyUsageDigitalSignature
+	if req.KeyAlgorithm == KeyAlgorithmRSA {
+		keyUsage |= x509.KeyUsageKeyEncipherment
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			Organization: []string{"Cockroach"},
+			CommonName:   req.Username,
+		},
+		URIs:                  uris,
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		KeyUsage:              keyUsage,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, caCert, key.Public(), caKey)
+	if err != nil {
+		return cert, err
+	}
+
+	cert.KeyPEM, err = encodePrivateKeyPEM(key)
+	if err != nil {
+		return cert, err
+	}
+	cert.KeyPKCS8PEM, err = encodePKCS8PrivateKeyPEM(key)
+	if err != nil {
+		return cert, err
+	}
+
+	cert.CertPEM = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	cert.SerialNumber = serialNumber
+	cert.NotBefore = notBefore
+	cert.NotAfter = notAfter
+
+	return cert, nil
+}
@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+)
+
+const (
+	lowerChars   = "abcdefghijklmnopqrstuvwxyz"
+	upperChars   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	numericChars = "0123456789"
+	specialChars = "!#$%&*()-_=+[]{}<>:?"
+)
+
+// PasswordClasses selects the character classes a generated password is drawn from
+type PasswordClasses struct {
+	Lower   bool
+	Upper   bool
+	Numeric bool
+	Special bool
+}
+
+func randomChar(chars string) (byte, error) {
+	idx, err := rand.Int(rand.Reader, big.NewInt(int64(len(chars))))
+	if err != nil {
+		return 0, err
+	}
+	return chars[idx.Int64()], nil
+}
+
+// GeneratePassword returns a cryptographically random password of the given
+// length containing at least one character of every selected class
+func GeneratePassword(length int, classes PasswordClasses) (string, error) {
+	sets := []string{}
+	if classes.Lower {
+		sets = append(sets, lowerChars)
+	}
+	if classes.Upper {
+		sets = append(sets, upperChars)
+	}
+	if classes.Numeric {
+		sets = append(sets, numericChars)
+	}
+	if classes.Special {
+		sets = append(sets, specialChars)
+	}
+
+	if len(sets) == 0 {
+		return "", fmt.Errorf("at least one character class must be enabled")
+	}
+	if length < len(sets) {
+		return "", fmt.Errorf("length %d is too short to include all %d character classes", length, len(sets))
+	}
+
+	all := ""
+	password := make([]byte, 0, length)
+	for _, set := range sets {
+		all += set
+
+		// Guarantee one character of each class
+		char, err := randomChar(set)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, char)
+	}
+
+	for len(password) < length {
+		char, err := randomChar(all)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, char)
+	}
+
+	// Shuffle so the guaranteed characters are not always first
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j.Int64()] = password[j.Int64()], password[i]
+	}
+
+	return string(password), nil
+}
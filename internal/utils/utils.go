@@ -32,10 +32,31 @@ func IsNilOrEmpty(str *string) bool {
 	return false
 }
 
+// allowedPrivileges are the privileges CockroachDB accepts and reports for
+// each object type
 var allowedPrivileges = map[string][]string{
-	"database": {"ALL", "CREATE", "CONNECT", "TEMPORARY"},
-	"table":    {"ALL", "SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES", "TRIGGER"},
+	"database": {"ALL", "BACKUP", "CONNECT", "CREATE", "DROP", "RESTORE", "ZONECONFIG"},
+	"table":    {"ALL", "BACKUP", "CHANGEFEED", "CREATE", "DELETE", "DROP", "INSERT", "SELECT", "UPDATE", "ZONECONFIG"},
 	"schema":   {"ALL", "CREATE", "USAGE"},
+	"sequence": {"ALL", "CREATE", "DROP", "INSERT", "SELECT", "UPDATE", "USAGE", "ZONECONFIG"},
+	"type":     {"ALL", "USAGE"},
+	"function": {"ALL", "EXECUTE"},
+	// Procedures are routines, like functions
+	"procedure":           {"ALL", "EXECUTE"},
+	"external_connection": {"ALL", "DROP", "USAGE"},
+	"system": {
+		"ALL", "BACKUP", "CANCELQUERY", "CHANGEFEED", "CONTROLJOB", "CREATEDB", "CREATELOGIN", "CREATEROLE",
+		"EXTERNALCONNECTION", "EXTERNALIOIMPLICITACCESS", "MODIFYCLUSTERSETTING", "MODIFYSQLCLUSTERSETTING",
+		"NOSQLLOGIN", "REPAIRCLUSTERMETADATA", "RESTORE", "VIEWACTIVITY", "VIEWACTIVITYREDACTED",
+		"VIEWCLUSTERMETADATA", "VIEWCLUSTERSETTING", "VIEWDEBUG", "VIEWJOB", "VIEWSYSTEMTABLE",
+	},
+}
+
+// unsupportedPrivileges are PostgreSQL privileges that CockroachDB doesn't
+// have, which earlier versions of the provider accepted
+var unsupportedPrivileges = map[string][]string{
+	"database": {"TEMPORARY"},
+	"table":    {"REFERENCES", "TRIGGER", "TRUNCATE"},
 }
 
 func ValidatePrivileges(ctx context.Context, objectType string, privileges []string) error {
@@ -45,9 +66,23 @@ func ValidatePrivileges(ctx context.Context, objectType string, privileges []str
 	}
 
 	for _, priv := range privileges {
-		if !SliceContainsStr(allowed, priv) {
+		if SliceContainsStr(unsupportedPrivileges[objectType], strings.ToUpper(priv)) {
+			return fmt.Errorf("%s is a PostgreSQL privilege that CockroachDB doesn't support on a %s, remove it from the privileges", priv, objectType)
+		}
+		if !SliceContainsStr(allowed, strings.ToUpper(priv)) {
 			return fmt.Errorf("%s is not an allowed privilege for object type %s", priv, objectType)
 		}
 	}
 	return nil
 }
+
+// ExpandAllPrivileges returns the privileges ALL stands for on an object type
+func ExpandAllPrivileges(objectType string) []string {
+	expanded := []string{}
+	for _, priv := range allowedPrivileges[objectType] {
+		if priv != "ALL" {
+			expanded = append(expanded, priv)
+		}
+	}
+	return expanded
+}
@@ -0,0 +1,61 @@
+package validators
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/helpers/validatordiag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = timestampValidator{}
+
+// timestampValidator validates that a string is an RFC 3339 timestamp or, when
+// allowed, the special value `infinity`.
+type timestampValidator struct {
+	AllowInfinity bool
+}
+
+// Description returns a plain text description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (v timestampValidator) Description(_ context.Context) string {
+	if v.AllowInfinity {
+		return "value must be an RFC 3339 timestamp or infinity"
+	}
+	return "value must be an RFC 3339 timestamp"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (v timestampValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString checks that the configured value parses as a timestamp.
+func (v timestampValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if v.AllowInfinity && value == "infinity" {
+		return
+	}
+
+	if _, err := time.Parse(time.RFC3339, value); err != nil {
+		resp.Diagnostics.Append(validatordiag.InvalidAttributeValueDiagnostic(
+			req.Path,
+			v.Description(ctx),
+			value,
+		))
+	}
+}
+
+// RFC3339 returns a validator which ensures a string is an RFC 3339 timestamp.
+func RFC3339() validator.String {
+	return timestampValidator{}
+}
+
+// RFC3339OrInfinity returns a validator which ensures a string is an RFC 3339
+// timestamp or `infinity`.
+func RFC3339OrInfinity() validator.String {
+	return timestampValidator{AllowInfinity: true}
+}